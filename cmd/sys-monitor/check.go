@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/internal/registry"
+)
+
+// primeDelay 预采样与正式采样的间隔（CPU使用率等基于两次采样差值计算的指标需要预采样）
+const primeDelay = time.Second
+
+// runCheck check子命令：立即采样一次所有资源并输出结果表格（不发送告警）
+func runCheck(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "配置文件路径")
+	fs.Parse(args)
+
+	cfg, err := configs.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "配置加载失败: %v\n", err)
+		return 1
+	}
+
+	collectors := registry.CreateAllCollectors(&cfg.Monitor)
+	for _, c := range collectors {
+		c.Collect()
+	}
+	time.Sleep(primeDelay)
+
+	code := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "采集器\t规则\t资源\t当前值\t阈值\t级别")
+	for _, c := range collectors {
+		samples, err := c.Collect()
+		if err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\t采样失败: %v\t-\t-\n", c.Name(), err)
+			code = 1
+			continue
+		}
+		for _, s := range samples {
+			if s.MetricsOnly() {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				c.Name(), s.Rule, s.Resource, formatSampleValue(s, s.Value), formatSampleValue(s, s.Threshold), s.Severity.Label())
+		}
+	}
+	w.Flush()
+	return code
+}
+
+// formatSampleValue 按采样单位格式化数值
+func formatSampleValue(s interfaces.Sample, v float64) string {
+	return fmt.Sprintf("%.2f%s", v, s.Unit)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"gopkg.in/yaml.v3"
+)
+
+// runDumpConfig dump-config子命令：输出已填充默认值且脱敏后的生效配置
+func runDumpConfig(args []string) int {
+	fs := flag.NewFlagSet("dump-config", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "配置文件路径")
+	asJSON := fs.Bool("json", false, "以JSON格式输出（默认YAML）")
+	fs.Parse(args)
+
+	cfg, err := configs.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "配置加载失败: %v\n", err)
+		return 1
+	}
+
+	if !*asJSON {
+		data, err := yaml.Marshal(cfg.Desensitized())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "配置序列化失败: %v\n", err)
+			return 1
+		}
+		os.Stdout.Write(data)
+		return 0
+	}
+
+	out, err := cfg.DesensitizedMap()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "配置序列化失败: %v\n", err)
+		return 1
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		fmt.Fprintf(os.Stderr, "配置输出失败: %v\n", err)
+		return 1
+	}
+	return 0
+}
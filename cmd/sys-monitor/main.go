@@ -1,60 +1,59 @@
-package main
-
-import (
-	"log"
-	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/Jwunai/sys-monitor-service/configs"
-	"github.com/Jwunai/sys-monitor-service/internal/monitor"
-	"github.com/Jwunai/sys-monitor-service/internal/registry"
-)
-
-func main() {
-	// ========== 1. 加载配置 ==========
-	cfg, err := configs.LoadConfig("./config.yml")
-	if err != nil {
-		log.Fatalf("配置加载失败: %v", err)
-	}
-
-	// ========== 2. 创建告警实例 ==========
-	alertSenders := registry.CreateAllEnabled(&cfg.Alert)
-	if len(alertSenders) == 0 {
-		log.Printf("未配置任何启用的告警渠道（钉钉/邮箱），告警功能将禁用")
-	} else {
-		// 打印启用的告警渠道
-		enabledAlerts := make([]string, 0, len(alertSenders))
-		for _, sender := range alertSenders {
-			enabledAlerts = append(enabledAlerts, sender.Name())
-		}
-		log.Printf("已启用告警渠道：%v", enabledAlerts)
-	}
-
-	// ========== 3. 初始化监控管理器 ==========
-	monitorMgr := monitor.NewManager(
-		cfg.Monitor.ServerName,
-		cfg.Monitor.CPU,
-		cfg.Monitor.Mem,
-		cfg.Monitor.Disk,
-		alertSenders,
-	)
-
-	// ========== 4. 启动监控避免阻塞主线程 ==========
-	log.Println("========== 启动监控服务 ==========")
-	go func() {
-		monitorMgr.Start()
-	}()
-	log.Println("监控服务启动成功")
-
-	// ========== 5. 退出逻辑 ==========
-	quit := make(chan os.Signal, 1)
-	// 监听Ctrl+C、kill等退出信号
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
-	<-quit // 阻塞等待退出信号
-
-	// ========== 6. 停止监控 ==========
-	log.Println("接收到退出信号，正在停止监控服务...")
-	monitorMgr.Stop()
-	log.Println("监控服务已正常退出")
-}
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// defaultConfigPath 默认配置文件路径
+const defaultConfigPath = "./config.yml"
+
+// usage 命令行帮助
+const usage = `sys-monitor：系统资源监控与多渠道告警服务
+
+用法：
+  sys-monitor [子命令] [参数]
+
+子命令：
+  run         运行监控服务（默认）
+  validate    严格校验配置文件（含未知配置项检查），有错误时以非0状态码退出
+  check       立即采样一次所有资源并输出结果表格
+  test-alert  通过指定接收器发送一条测试告警
+  status      查询运行中服务的状态（需启用http.listen）
+  dump-config 输出已填充默认值且脱敏后的生效配置
+
+通用参数：
+  --config    配置文件路径（默认 ./config.yml）
+
+执行 sys-monitor <子命令> -h 查看子命令参数
+`
+
+func main() {
+	// 无子命令时默认运行服务，兼容原有的直接启动方式
+	cmd, args := "run", os.Args[1:]
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		cmd, args = args[0], args[1:]
+	}
+
+	var code int
+	switch cmd {
+	case "run":
+		code = runDaemon(args)
+	case "validate":
+		code = runValidate(args)
+	case "check":
+		code = runCheck(args)
+	case "test-alert":
+		code = runTestAlert(args)
+	case "status":
+		code = runStatus(args)
+	case "dump-config":
+		code = runDumpConfig(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "未知子命令: %s\n\n%s", cmd, usage)
+		code = 2
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/api"
+	"github.com/Jwunai/sys-monitor-service/internal/monitor"
+	"github.com/Jwunai/sys-monitor-service/internal/registry"
+)
+
+// reloadConfig 重新加载配置并应用到运行中的服务（新配置校验不通过时保留原配置）
+func reloadConfig(configPath string, strict bool, current *configs.AppConfig, monitorMgr *monitor.Manager, httpServer *api.Server) *configs.AppConfig {
+	log.Println("========== 开始热加载配置 ==========")
+	cfg, err := configs.LoadValidConfig(configPath, strict)
+	if err != nil {
+		log.Printf("❌ 配置热加载失败，继续使用原配置:\n%v", err)
+		return current
+	}
+
+	alertSenders := registry.CreateAllEnabled(&cfg.Alert)
+	collectors := registry.CreateAllCollectors(&cfg.Monitor)
+	monitorMgr.Reload(cfg.Monitor.ServerName, collectors, alertSenders, cfg.Alert)
+	if httpServer != nil {
+		httpServer.SetConfig(cfg)
+	}
+
+	// 以下配置需重启进程才能生效
+	if cfg.Alert.Outbox != current.Alert.Outbox {
+		log.Println("⚠️  告警重试队列配置已变更，需重启服务后生效")
+	}
+	if cfg.HTTP != current.HTTP {
+		log.Println("⚠️  HTTP服务配置已变更，需重启服务后生效")
+	}
+	if cfg.Reload != current.Reload {
+		log.Println("⚠️  配置热加载设置已变更，需重启服务后生效")
+	}
+	return cfg
+}
+
+// watchConfigFile 定时检查配置文件修改时间与大小，变更时通知重新加载（阻塞直到ctx取消）
+func watchConfigFile(ctx context.Context, configPath string, interval time.Duration, changed chan<- struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastMod time.Time
+	var lastSize int64
+	if info, err := os.Stat(configPath); err == nil {
+		lastMod, lastSize = info.ModTime(), info.Size()
+	}
+
+	log.Printf("配置文件变更检测已启动 | 文件: %s | 检查间隔: %v", configPath, interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(configPath)
+			if err != nil {
+				continue // 编辑器保存时可能短暂不存在，下次再检查
+			}
+			if info.ModTime().Equal(lastMod) && info.Size() == lastSize {
+				continue
+			}
+			lastMod, lastSize = info.ModTime(), info.Size()
+			log.Println("检测到配置文件变更")
+			select {
+			case changed <- struct{}{}:
+			default: // 已有待处理的重新加载
+			}
+		}
+	}
+}
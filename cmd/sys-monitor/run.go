@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/api"
+	"github.com/Jwunai/sys-monitor-service/internal/monitor"
+	"github.com/Jwunai/sys-monitor-service/internal/outbox"
+	"github.com/Jwunai/sys-monitor-service/internal/registry"
+)
+
+// runDaemon run子命令：以守护进程方式运行监控服务
+func runDaemon(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "配置文件路径")
+	strict := fs.Bool("strict", false, "严格模式（配置存在任何问题时拒绝启动，热加载同样按严格模式校验）")
+	fs.Parse(args)
+
+	// ========== 1. 加载配置 ==========
+	cfg, err := loadDaemonConfig(*configPath, *strict)
+	if err != nil {
+		log.Printf("配置加载失败: %v", err)
+		return 1
+	}
+
+	// ========== 2. 创建告警实例 ==========
+	alertSenders := registry.CreateAllEnabled(&cfg.Alert)
+	if len(alertSenders) == 0 {
+		log.Printf("未配置任何启用的告警渠道（钉钉/邮箱），告警功能将禁用")
+	} else {
+		// 打印启用的告警渠道
+		enabledAlerts := make([]string, 0, len(alertSenders))
+		for _, sender := range alertSenders {
+			enabledAlerts = append(enabledAlerts, sender.Name())
+		}
+		sort.Strings(enabledAlerts)
+		log.Printf("已启用告警渠道：%v", enabledAlerts)
+	}
+
+	// ========== 3. 打开告警重试队列 ==========
+	var retryQueue *outbox.Outbox
+	if !cfg.Alert.Outbox.Disabled {
+		retryQueue, err = outbox.Open(cfg.Alert.Outbox)
+		if err != nil {
+			log.Printf("告警重试队列打开失败，发送失败的告警将不会重试: %v", err)
+		}
+	}
+
+	// ========== 4. 创建采集器并初始化监控管理器 ==========
+	collectors := registry.CreateAllCollectors(&cfg.Monitor)
+	monitorMgr := monitor.NewManager(
+		cfg.Monitor.ServerName,
+		collectors,
+		alertSenders,
+		cfg.Alert,
+		retryQueue,
+	)
+
+	// ========== 5. 启动监控（Start不阻塞，需在热加载前完成，保证各采集器协程已就绪） ==========
+	log.Println("========== 启动监控服务 ==========")
+	monitorMgr.Start()
+	log.Println("监控服务启动成功")
+
+	// 可选：启动HTTP服务（Prometheus指标导出、JSON状态查询）
+	httpServer := api.NewServer(cfg, monitorMgr)
+	if httpServer != nil {
+		httpServer.Start()
+	}
+
+	// ========== 6. 退出与热加载逻辑 ==========
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	configChanged := make(chan struct{}, 1)
+	if cfg.Reload.Watch {
+		go watchConfigFile(ctx, *configPath, cfg.Reload.WatchInterval.Duration(), configChanged)
+	}
+
+	quit := make(chan os.Signal, 1)
+	// 监听Ctrl+C、kill等退出信号，SIGHUP触发配置热加载
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
+wait:
+	for {
+		select {
+		case sig := <-quit:
+			if sig != syscall.SIGHUP {
+				break wait
+			}
+			log.Println("接收到SIGHUP信号")
+			cfg = reloadConfig(*configPath, *strict, cfg, monitorMgr, httpServer)
+		case <-configChanged:
+			cfg = reloadConfig(*configPath, *strict, cfg, monitorMgr, httpServer)
+		}
+	}
+
+	// ========== 7. 停止监控 ==========
+	log.Println("接收到退出信号，正在停止监控服务...")
+	if httpServer != nil {
+		httpServer.Stop()
+	}
+	monitorMgr.Stop()
+	log.Println("监控服务已正常退出")
+	return 0
+}
+
+// loadDaemonConfig 加载服务配置（严格模式下配置存在任何问题即返回错误，拒绝启动）
+func loadDaemonConfig(configPath string, strict bool) (*configs.AppConfig, error) {
+	if !strict {
+		return configs.LoadConfig(configPath)
+	}
+	log.Println("========== 开始加载配置文件（严格模式） ==========")
+	cfg, err := configs.LoadValidConfig(configPath, true)
+	if err != nil {
+		return nil, fmt.Errorf("严格模式下配置校验不通过，拒绝启动：\n%w", err)
+	}
+	log.Println("========== 配置文件加载完成 ==========")
+	return cfg, nil
+}
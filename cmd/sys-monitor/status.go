@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/api"
+)
+
+// runStatus status子命令：通过HTTP状态接口查询运行中服务的状态
+func runStatus(args []string) int {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "配置文件路径（用于读取http.listen）")
+	addr := fs.String("addr", "", "服务地址（如127.0.0.1:9105，默认取配置中的http.listen）")
+	rawJSON := fs.Bool("json", false, "直接输出JSON")
+	fs.Parse(args)
+
+	if *addr == "" {
+		cfg, err := configs.LoadConfig(*configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "配置加载失败: %v\n", err)
+			return 1
+		}
+		if cfg.HTTP.Listen == "" {
+			fmt.Fprintln(os.Stderr, "配置中未启用HTTP服务（http.listen为空），请通过--addr指定服务地址")
+			return 2
+		}
+		*addr = dialAddr(cfg.HTTP.Listen)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + *addr + "/api/v1/status")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "查询服务状态失败: %v\n", err)
+		return 1
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "读取服务状态失败: %v\n", err)
+		return 1
+	}
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "查询服务状态失败: HTTP %d %s\n", resp.StatusCode, strings.TrimSpace(string(body)))
+		return 1
+	}
+	if *rawJSON {
+		os.Stdout.Write(body)
+		return 0
+	}
+
+	var status api.StatusResponse
+	if err := json.Unmarshal(body, &status); err != nil {
+		fmt.Fprintf(os.Stderr, "解析服务状态失败: %v\n", err)
+		return 1
+	}
+	printStatus(status)
+	return 0
+}
+
+// dialAddr 将监听地址转换为本机访问地址（如":9105"→"127.0.0.1:9105"）
+func dialAddr(listen string) string {
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return listen
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// printStatus 以表格形式输出服务状态
+func printStatus(status api.StatusResponse) {
+	fmt.Printf("服务器: %s | 主机: %s（%s）| 运行时长: %s\n\n", status.Host.ServerName, status.Host.Hostname, status.Host.OS, status.Uptime)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "采集器\t规则\t资源\t当前值\t阈值\t级别\t采样时间")
+	for _, c := range status.Collectors {
+		if c.LastError != "" {
+			fmt.Fprintf(w, "%s\t-\t-\t采样失败: %s\t-\t-\t%s\n", c.Name, c.LastError, c.LastErrorAt.Format("15:04:05"))
+		}
+		for _, s := range c.Samples {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%.2f%s\t%.2f%s\t%s\t%s\n",
+				c.Name, s.Rule, s.Resource, s.Value, s.Unit, s.Threshold, s.Unit, s.Severity.Label(), c.CollectedAt.Format("15:04:05"))
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\n活动告警（%d条）:\n", len(status.Alerts))
+	if len(status.Alerts) > 0 {
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "规则\t资源\t状态\t级别\t当前值\t开始时间")
+		for _, a := range status.Alerts {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%.2f%s\t%s\n",
+				a.Rule, a.Resource, a.State, a.Severity.Label(), a.Value, a.Unit, a.StartsAt.Format("2006-01-02 15:04:05"))
+		}
+		w.Flush()
+	}
+
+	fmt.Printf("\n告警接收器: [%s]\n", strings.Join(status.Receivers, ", "))
+	if status.Outbox != nil {
+		fmt.Printf("重试队列: 待重试 %d 条", status.Outbox.Depth)
+		if !status.Outbox.Oldest.IsZero() {
+			fmt.Printf("，最早 %s", status.Outbox.Oldest.Format("2006-01-02 15:04:05"))
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/internal/registry"
+	"github.com/Jwunai/sys-monitor-service/pkg"
+)
+
+// runTestAlert test-alert子命令：通过指定接收器发送一条测试告警（不经过路由、级别过滤与限流）
+func runTestAlert(args []string) int {
+	fs := flag.NewFlagSet("test-alert", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "配置文件路径")
+	receiver := fs.String("receiver", "", "接收器名称（alert.receivers中的name，必填）")
+	severity := fs.String("severity", "warning", "测试告警级别（warning/critical）")
+	fs.Parse(args)
+
+	sev, err := interfaces.ParseSeverity(*severity)
+	if err != nil || sev == interfaces.SeverityNone {
+		fmt.Fprintf(os.Stderr, "无效的告警级别: %s（可选 warning/critical）\n", *severity)
+		return 2
+	}
+
+	cfg, err := configs.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "配置加载失败: %v\n", err)
+		return 1
+	}
+
+	alertSenders := registry.CreateAllEnabled(&cfg.Alert)
+	sender, ok := alertSenders[*receiver]
+	if !ok {
+		names := make([]string, 0, len(alertSenders))
+		for name := range alertSenders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(os.Stderr, "告警接收器[%s]不存在或未启用，可用接收器: [%s]\n", *receiver, strings.Join(names, ", "))
+		return 2
+	}
+
+	now := time.Now()
+	host := interfaces.HostInfo{
+		ServerName: cfg.Monitor.ServerName,
+		Hostname:   pkg.GetHostname(),
+		OS:         pkg.GetOS(),
+	}
+	alert := interfaces.Alert{
+		Rule:     "test_alert",
+		Resource: "测试",
+		Severity: sev,
+		State:    interfaces.AlertFiring,
+		Labels: map[string]string{
+			"rule":     "test_alert",
+			"severity": sev.String(),
+			"server":   host.ServerName,
+		},
+		StartsAt:    now,
+		Host:        host,
+		Title:       fmt.Sprintf("【%s】测试告警", sev.Label()),
+		Description: "这是一条由 sys-monitor test-alert 发送的测试告警，用于验证告警渠道配置，无需处理。",
+	}
+
+	if err := sender.SendAlert(alert); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ 告警[%s]测试发送失败: %v\n", sender.Name(), err)
+		return 1
+	}
+	fmt.Printf("✅ 告警[%s]测试发送成功\n", sender.Name())
+	return 0
+}
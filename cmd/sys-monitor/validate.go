@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+)
+
+// runValidate validate子命令：加载并校验配置文件
+func runValidate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "配置文件路径")
+	strict := fs.Bool("strict", true, "严格模式（检查未知配置项，如拼写错误的配置名）")
+	fs.Parse(args)
+
+	if _, err := configs.LoadValidConfig(*configPath, *strict); err != nil {
+		var issues configs.Issues
+		if errors.As(err, &issues) {
+			fmt.Fprintf(os.Stderr, "❌ 配置文件校验不通过: %s（共%d个问题）\n%v\n", *configPath, len(issues), err)
+		} else {
+			fmt.Fprintf(os.Stderr, "❌ 配置文件校验不通过: %s\n%v\n", *configPath, err)
+		}
+		return 1
+	}
+	fmt.Printf("✅ 配置文件校验通过: %s\n", *configPath)
+	return 0
+}
@@ -1,8 +1,9 @@
-// configs/alerts_config/dingtalk.go
-package alert_config
-
-// DingTalkConfig 钉钉告警专属配置
-type DingTalkConfig struct {
-	Token  string `yaml:"token"`  // 钉钉机器人token
-	Secret string `yaml:"secret"` // 钉钉机器人secret
-}
+// configs/alerts_config/dingtalk.go
+package alert_config
+
+// DingTalkConfig 钉钉告警专属配置
+type DingTalkConfig struct {
+	Token         string `yaml:"token" sensitive:"secret"`  // 钉钉机器人token
+	Secret        string `yaml:"secret" sensitive:"secret"` // 钉钉机器人secret
+	AtAllSeverity string `yaml:"at_all_severity"`           // 达到该级别时@所有人（warning/critical，空表示不@）
+}
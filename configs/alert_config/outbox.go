@@ -0,0 +1,13 @@
+// configs/alert_config/outbox.go
+package alert_config
+
+import "github.com/Jwunai/sys-monitor-service/pkg"
+
+// OutboxConfig 告警发送失败重试队列配置（本地文件持久化，重启后继续重试）
+type OutboxConfig struct {
+	Disabled       bool         `yaml:"disabled"`        // 是否禁用重试队列（禁用后发送失败的告警直接丢弃）
+	Path           string       `yaml:"path"`            // 队列文件路径
+	MaxAge         pkg.Duration `yaml:"max_age"`         // 告警最长保留时长，超过后放弃重试
+	InitialBackoff pkg.Duration `yaml:"initial_backoff"` // 首次重试等待时间（之后按指数退避翻倍）
+	MaxBackoff     pkg.Duration `yaml:"max_backoff"`     // 单次重试等待时间上限
+}
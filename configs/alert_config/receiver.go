@@ -0,0 +1,18 @@
+// configs/alert_config/receiver.go
+package alert_config
+
+// ReceiverConfig 告警接收器配置（同一渠道类型可配置多个命名实例，如每个团队一个钉钉机器人）
+type ReceiverConfig struct {
+	Name           string           `yaml:"name"`         // 接收器名称（唯一，供告警路由引用及日志展示，默认同type）
+	Type           string           `yaml:"type"`         // 渠道类型（对应告警注册器中的key，如dingtalk/email）
+	MinSeverity    string           `yaml:"min_severity"` // 接收的最低告警级别（warning/critical，空表示全部接收）
+	RateLimit      RateLimitConfig  `yaml:"rate_limit"`   // 发送限流（未配置时使用渠道类型的默认值）
+	DingTalkConfig `yaml:",inline"` // 钉钉专属配置（type=dingtalk时生效）
+	EmailConfig    `yaml:",inline"` // 邮箱专属配置（type=email时生效）
+}
+
+// RateLimitConfig 接收器发送限流配置（令牌桶，超限的告警合并为一条汇总消息）
+type RateLimitConfig struct {
+	PerMinute int `yaml:"per_minute"` // 每分钟最多发送条数（0表示使用渠道类型默认值，负数表示不限流）
+	Burst     int `yaml:"burst"`      // 允许的突发条数（令牌桶容量）
+}
@@ -0,0 +1,12 @@
+// configs/alert_config/route.go
+package alert_config
+
+// RouteConfig 告警路由节点（按标签匹配选择接收器，支持嵌套子路由）
+// 匹配规则：按顺序匹配子路由，命中第一个后停止（continue=true时继续匹配后续兄弟路由）；
+// 没有子路由命中时使用当前节点的receivers，根节点即默认路由
+type RouteConfig struct {
+	Match     map[string]string `yaml:"match"`     // 标签精确匹配（resource/severity/mountpoint/server/rule，全部相等才命中）
+	Receivers []string          `yaml:"receivers"` // 命中后发送的接收器（引用receivers中的name，为空时继承父路由）
+	Continue  bool              `yaml:"continue"`  // 命中后是否继续匹配后续兄弟路由
+	Routes    []RouteConfig     `yaml:"routes"`    // 子路由
+}
@@ -0,0 +1,95 @@
+// configs/desensitize.go
+package configs
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Jwunai/sys-monitor-service/pkg"
+	"gopkg.in/yaml.v3"
+)
+
+// sensitiveTag 敏感配置项标记，取值为脱敏方式（如 `sensitive:"secret"`），新增渠道的配置项打上标记即可自动脱敏
+const sensitiveTag = "sensitive"
+
+// maskers 各脱敏方式对应的脱敏函数（未知方式按secret处理）
+var maskers = map[string]func(string) string{
+	"secret":   pkg.Desensitize,      // 密钥、token：保留前后若干位
+	"email":    pkg.DesensitizeEmail, // 邮箱地址：保留前缀前2位与域名
+	"password": pkg.DesensitizeSMTP,  // 密码、授权码
+}
+
+// Desensitized 返回脱敏后的配置副本（带sensitive标记的配置项已脱敏，用于对外展示）
+// 通过环境变量或文件引用得到的配置项展示为原始引用（如"${DINGTALK_TOKEN}"），不展示实际值
+func (c AppConfig) Desensitized() AppConfig {
+	root := reflect.ValueOf(&c).Elem()
+	cloneSlices(root)
+
+	walkFields(root, "", envPrefix, func(path, _ string, field reflect.Value, tag reflect.StructTag) {
+		mask := func(s string) string { return s }
+		if kind, ok := tag.Lookup(sensitiveTag); ok {
+			if mask, ok = maskers[kind]; !ok {
+				mask = pkg.Desensitize
+			}
+		}
+
+		switch {
+		case field.Kind() == reflect.String:
+			if ref, ok := c.refs[path]; ok {
+				field.SetString(ref)
+			} else {
+				field.SetString(mask(field.String()))
+			}
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+			for i := 0; i < field.Len(); i++ {
+				item := field.Index(i)
+				if ref, ok := c.refs[fmt.Sprintf("%s[%d]", path, i)]; ok {
+					item.SetString(ref)
+				} else {
+					item.SetString(mask(item.String()))
+				}
+			}
+		}
+	})
+	return c
+}
+
+// DesensitizedMap 返回脱敏后的配置（通用结构，用于输出JSON）
+// 先按yaml标签序列化再转为通用结构，保证字段名与配置文件一致
+func (c AppConfig) DesensitizedMap() (map[string]interface{}, error) {
+	data, err := yaml.Marshal(c.Desensitized())
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// cloneSlices 复制结构体中的所有列表（含嵌套结构体内的列表），避免修改副本时影响原配置
+func cloneSlices(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Struct:
+			cloneSlices(field)
+		case reflect.Slice:
+			if field.IsNil() {
+				continue
+			}
+			copied := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+			reflect.Copy(copied, field)
+			field.Set(copied)
+			if copied.Type().Elem().Kind() == reflect.Struct {
+				for j := 0; j < copied.Len(); j++ {
+					cloneSlices(copied.Index(j))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// configs/issues.go
+package configs
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Issue 单个配置问题（Line/Column为配置文件中的位置，0表示文件中无对应配置项，如使用了默认值）
+type Issue struct {
+	Path    string // 配置项路径（如"monitor.cpu_threshold"、"alert.receivers[0].secret"）
+	Message string // 问题描述
+	Line    int    // 行号
+	Column  int    // 列号
+}
+
+// String 格式化为"第N行第M列 路径: 描述"
+func (i Issue) String() string {
+	var b strings.Builder
+	switch {
+	case i.Line > 0 && i.Column > 0:
+		fmt.Fprintf(&b, "第%d行第%d列 ", i.Line, i.Column)
+	case i.Line > 0:
+		fmt.Fprintf(&b, "第%d行 ", i.Line)
+	}
+	if i.Path != "" {
+		fmt.Fprintf(&b, "%s: ", i.Path)
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// Issues 配置问题列表（实现error接口，每个问题一行）
+type Issues []Issue
+
+// Error 实现error接口
+func (is Issues) Error() string {
+	lines := make([]string, len(is))
+	for i, issue := range is {
+		lines[i] = issue.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// add 追加一个配置问题
+func (is *Issues) add(path, format string, args ...interface{}) {
+	*is = append(*is, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// locate 根据配置项路径补全问题在配置文件中的位置（路径不存在时使用最近的上级配置项位置）
+func (is Issues) locate(root *yaml.Node) {
+	for i := range is {
+		if is[i].Line > 0 || is[i].Path == "" {
+			continue
+		}
+		if node := findNode(root, is[i].Path); node != nil {
+			is[i].Line, is[i].Column = node.Line, node.Column
+		}
+	}
+}
+
+// sortByPosition 按配置文件中的位置排序（无位置的问题排在最后）
+func (is Issues) sortByPosition() {
+	sort.SliceStable(is, func(i, j int) bool {
+		a, b := is[i], is[j]
+		if (a.Line == 0) != (b.Line == 0) {
+			return b.Line == 0
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// pathIndexPattern 匹配路径中的数组下标（如"receivers[0]"）
+var pathIndexPattern = regexp.MustCompile(`^(.*)\[(\d+)\]$`)
+
+// findNode 按路径查找YAML节点（映射节点返回键节点，便于定位到配置项名称）
+func findNode(root *yaml.Node, path string) *yaml.Node {
+	node := documentContent(root)
+	if node == nil {
+		return nil
+	}
+	found := node
+	for _, part := range strings.Split(path, ".") {
+		key, index := part, -1
+		if m := pathIndexPattern.FindStringSubmatch(part); m != nil {
+			key = m[1]
+			index, _ = strconv.Atoi(m[2])
+		}
+
+		keyNode, value := mappingValue(node, key)
+		if value == nil {
+			return found
+		}
+		found, node = keyNode, value
+		if index >= 0 {
+			if node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+				return found
+			}
+			node = node.Content[index]
+			found = node
+		}
+	}
+	return found
+}
+
+// documentContent 返回文档根节点下的实际内容节点
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return node.Content[0]
+	}
+	return node
+}
+
+// mappingValue 查找映射节点中指定键的键节点与值节点
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// checkKnownFields 检查YAML中是否存在结构体未定义的配置项（如拼写错误的cpu_treshold）
+func checkKnownFields(node *yaml.Node, t reflect.Type, path string) Issues {
+	var issues Issues
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.DocumentNode || node.Kind == yaml.AliasNode {
+		if node.Kind == yaml.AliasNode {
+			return checkKnownFields(node.Alias, t, path)
+		}
+		return checkKnownFields(documentContent(node), t, path)
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			childPath := joinPath(path, keyNode.Value)
+			fieldType, ok := fields[keyNode.Value]
+			if !ok {
+				issues = append(issues, Issue{
+					Path:    childPath,
+					Message: fmt.Sprintf("未知的配置项（%s中没有%s）", describePath(path), keyNode.Value),
+					Line:    keyNode.Line,
+					Column:  keyNode.Column,
+				})
+				continue
+			}
+			issues = append(issues, checkKnownFields(valueNode, fieldType, childPath)...)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return nil
+		}
+		for i, item := range node.Content {
+			issues = append(issues, checkKnownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			issues = append(issues, checkKnownFields(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))...)
+		}
+	}
+	return issues
+}
+
+// yamlFields 返回结构体可识别的YAML键及对应类型（展开inline内嵌结构体）
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			for k, v := range yamlFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// joinPath 拼接配置项路径
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// describePath 描述配置层级（根层级显示为"根节点"）
+func describePath(path string) string {
+	if path == "" {
+		return "根节点"
+	}
+	return path
+}
+
+// typeErrorLinePattern 匹配yaml.v3类型错误中的行号（如"line 5: cannot unmarshal ..."）
+var typeErrorLinePattern = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// typeErrorValuePattern 匹配yaml.v3类型错误中的节点标签与值（如"cannot unmarshal !!str `abc` into float64"）
+// 序列与映射节点不输出值，标量值超过10个字符时截断为"前7个字符..."
+var typeErrorValuePattern = regexp.MustCompile("^cannot unmarshal (\\S+)(?: `(.*)`)? into ")
+
+// typeErrorIssues 将yaml.v3的类型错误转换为配置问题列表（按行号与配置值在节点树中定位出错的配置项）
+func typeErrorIssues(err *yaml.TypeError, root *yaml.Node) Issues {
+	issues := make(Issues, 0, len(err.Errors))
+	for _, msg := range err.Errors {
+		issue := Issue{Message: msg}
+		if m := typeErrorLinePattern.FindStringSubmatch(msg); m != nil {
+			issue.Line, _ = strconv.Atoi(m[1])
+			issue.Message = "配置值类型错误: " + m[2]
+
+			if v := typeErrorValuePattern.FindStringSubmatch(m[2]); v != nil {
+				if node, path := typeErrorNode(documentContent(root), "", issue.Line, v[1], v[2]); node != nil {
+					issue.Path, issue.Column = path, node.Column
+				}
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// typeErrorNode 按行号、节点标签与值查找类型错误对应的节点及其配置项路径（同一行有多个节点时按标签与值区分）
+func typeErrorNode(node *yaml.Node, path string, line int, tag, value string) (*yaml.Node, string) {
+	if node == nil {
+		return nil, ""
+	}
+	if node.Line == line && node.ShortTag() == tag &&
+		(node.Kind != yaml.ScalarNode || typeErrorValueMatches(node.Value, value)) {
+		return node, path
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := joinPath(path, node.Content[i].Value)
+			if found, p := typeErrorNode(node.Content[i+1], childPath, line, tag, value); found != nil {
+				return found, p
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if found, p := typeErrorNode(item, fmt.Sprintf("%s[%d]", path, i), line, tag, value); found != nil {
+				return found, p
+			}
+		}
+	}
+	return nil, ""
+}
+
+// typeErrorValueMatches 判断节点值是否与类型错误中（可能被截断的）值一致
+func typeErrorValueMatches(nodeValue, errValue string) bool {
+	if len(nodeValue) > 10 {
+		return nodeValue[:7]+"..." == errValue
+	}
+	return nodeValue == errValue
+}
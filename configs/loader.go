@@ -1,152 +1,639 @@
-// configs/loader.go
-package configs
-
-import (
-	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-	"time"
-
-	"github.com/Jwunai/sys-monitor-service/configs/alert_config"  // 替换为你的实际module名
-	"github.com/Jwunai/sys-monitor-service/configs/monitor_config" // 替换为你的实际module名
-	"github.com/Jwunai/sys-monitor-service/pkg"             // 工具包（系统信息/脱敏等）
-	"gopkg.in/yaml.v3"
-)
-
-// MonitorConfig 监控总配置（整合server_name + 各资源专属配置）
-type MonitorConfig struct {
-	ServerName string             `yaml:"server_name"` // 服务器名称（告警标题标识）
-	CPU        monitor_config.CPUConfig  `yaml:",inline"`     // 内嵌CPU配置（匹配cpu_interval/cpu_threshold）
-	Disk       monitor_config.DiskConfig `yaml:",inline"`     // 内嵌磁盘配置（匹配disk_interval等）
-	Mem        monitor_config.MemConfig  `yaml:",inline"`     // 内嵌内存配置（匹配mem_interval等）
-}
-
-// AlertConfig 告警总配置（无变化，匹配alert嵌套层级）
-type AlertConfig struct {
-	DingTalk alert_config.DingTalkConfig `yaml:"dingtalk"` // 匹配alert.dingtalk
-	Email    alert_config.EmailConfig    `yaml:"email"`    // 匹配alert.email
-	//SMS      alerts.SMSConfig      `yaml:"sms"`      // 匹配alert.sms
-}
-
-// AppConfig 全局配置（匹配YAML根层级）
-type AppConfig struct {
-	Monitor MonitorConfig `yaml:"monitor"` // 匹配monitor根层级
-	Alert   AlertConfig   `yaml:"alert"`   // 匹配alert根层级
-}
-
-// LoadConfig 加载并解析配置文件（核心逻辑不变，仅调整默认值）
-func LoadConfig(configPath string) (*AppConfig, error) {
-	log.Println("========== 开始加载配置文件 ==========")
-
-	// 1. 处理配置文件路径
-	absPath, err := filepath.Abs(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("解析配置文件路径失败：%w", err)
-	}
-
-	// 2. 读取配置文件
-	data, err := os.ReadFile(absPath)
-	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败：%w", err)
-	}
-
-	// 3. 解析YAML到结构体
-	var cfg AppConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("解析YAML配置失败：%w", err)
-	}
-
-	// 4. 填充默认值（适配新的独立间隔）
-	setDefaultConfig(&cfg)
-
-	// 5. 校验配置合法性
-	if err := validateConfig(&cfg); err != nil {
-		log.Printf("【警告】配置校验不通过：%v", err)
-	}
-
-	log.Println("========== 配置文件加载完成 ==========")
-	return &cfg, nil
-}
-
-// setDefaultConfig 填充配置默认值（适配独立的采样间隔）
-func setDefaultConfig(cfg *AppConfig) {
-	// 服务器名称默认值
-	if cfg.Monitor.ServerName == "" {
-		cfg.Monitor.ServerName = fmt.Sprintf("sys-monitor-%s", pkg.GetOS())
-	}
-
-	// CPU配置默认值
-	if cfg.Monitor.CPU.Interval == 0 {
-		cfg.Monitor.CPU.Interval = 30 * time.Second
-	}
-	if cfg.Monitor.CPU.Threshold == 0 {
-		cfg.Monitor.CPU.Threshold = 80.0
-	}
-
-	// 内存配置默认值
-	if cfg.Monitor.Mem.Interval == 0 {
-		cfg.Monitor.Mem.Interval = 30 * time.Second
-	}
-	if cfg.Monitor.Mem.AvailableThreshold == 0 {
-		cfg.Monitor.Mem.AvailableThreshold = 2.0
-	}
-
-	// 磁盘配置默认值
-	if cfg.Monitor.Disk.Interval == 0 {
-		cfg.Monitor.Disk.Interval = 60 * time.Second
-	}
-	if cfg.Monitor.Disk.UsageThreshold == 0 {
-		cfg.Monitor.Disk.UsageThreshold = 85.0
-	}
-	if len(cfg.Monitor.Disk.MonitorDisks) == 0 {
-		cfg.Monitor.Disk.MonitorDisks = pkg.GetDefaultDisks() // 自动识别系统磁盘
-	}
-}
-
-// validateConfig 校验配置合法性（无变化）
-func validateConfig(cfg *AppConfig) error {
-	var errMsg []string
-
-	// CPU配置校验
-	if cfg.Monitor.CPU.Threshold < 0 || cfg.Monitor.CPU.Threshold > 100 {
-		errMsg = append(errMsg, "CPU告警阈值必须在0-100之间")
-	}
-	if cfg.Monitor.CPU.Interval < 5*time.Second { // 最小间隔5秒，避免高频采样
-		errMsg = append(errMsg, "CPU采样间隔不能小于5秒")
-	}
-
-	// 内存配置校验
-	if cfg.Monitor.Mem.AvailableThreshold < 0 {
-		errMsg = append(errMsg, "可用内存阈值不能为负数")
-	}
-	if cfg.Monitor.Mem.Interval < 5*time.Second {
-		errMsg = append(errMsg, "内存采样间隔不能小于5秒")
-	}
-
-	// 磁盘配置校验
-	if cfg.Monitor.Disk.UsageThreshold < 0 || cfg.Monitor.Disk.UsageThreshold > 100 {
-		errMsg = append(errMsg, "磁盘使用率阈值必须在0-100之间")
-	}
-	if cfg.Monitor.Disk.Interval < 5*time.Second {
-		errMsg = append(errMsg, "磁盘采样间隔不能小于5秒")
-	}
-
-	// 告警配置校验
-	if cfg.Alert.DingTalk.Token != "" && cfg.Alert.DingTalk.Secret == "" {
-		errMsg = append(errMsg, "配置了钉钉Token但未配置Secret")
-	}
-	if cfg.Alert.Email.From != "" {
-		if cfg.Alert.Email.SmtpHost == "" {
-			errMsg = append(errMsg, "配置了发件人邮箱但未配置SMTP服务器")
-		}
-		if cfg.Alert.Email.SmtpPort == 0 {
-			errMsg = append(errMsg, "配置了发件人邮箱但未配置SMTP端口")
-		}
-	}
-
-	if len(errMsg) > 0 {
-		return fmt.Errorf("%v", errMsg)
-	}
-	return nil
-}
+// configs/loader.go
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config"   // 替换为你的实际module名
+	"github.com/Jwunai/sys-monitor-service/configs/monitor_config" // 替换为你的实际module名
+	"github.com/Jwunai/sys-monitor-service/configs/server_config"
+	"github.com/Jwunai/sys-monitor-service/pkg" // 工具包（系统信息/脱敏等）
+	"gopkg.in/yaml.v3"
+)
+
+// MonitorConfig 监控总配置（整合server_name + 各资源专属配置）
+type MonitorConfig struct {
+	ServerName string                    `yaml:"server_name"` // 服务器名称（告警标题标识）
+	CPU        monitor_config.CPUConfig  `yaml:",inline"`     // 内嵌CPU配置（匹配cpu_interval/cpu_threshold）
+	Disk       monitor_config.DiskConfig `yaml:",inline"`     // 内嵌磁盘配置（匹配disk_interval等）
+	Mem        monitor_config.MemConfig  `yaml:",inline"`     // 内嵌内存配置（匹配mem_interval等）
+	Swap       monitor_config.SwapConfig `yaml:",inline"`     // 内嵌Swap配置（匹配swap_interval等）
+	Load       monitor_config.LoadConfig `yaml:",inline"`     // 内嵌负载配置（匹配load_interval等）
+}
+
+// AlertConfig 告警总配置（无变化，匹配alert嵌套层级）
+type AlertConfig struct {
+	RepeatInterval pkg.Duration                  `yaml:"repeat_interval"`    // 告警持续期间的重复提醒间隔
+	Route          alert_config.RouteConfig      `yaml:"route"`              // 告警路由（按标签选择接收器，未配置时发送到所有渠道）
+	Receivers      []alert_config.ReceiverConfig `yaml:"receivers"`          // 命名告警接收器列表（同一类型可配置多个）
+	Outbox         alert_config.OutboxConfig     `yaml:"outbox"`             // 发送失败重试队列
+	DingTalk       alert_config.DingTalkConfig   `yaml:"dingtalk,omitempty"` // 匹配alert.dingtalk（兼容旧配置，加载时转换为name=dingtalk的接收器）
+	Email          alert_config.EmailConfig      `yaml:"email,omitempty"`    // 匹配alert.email（兼容旧配置，加载时转换为name=email的接收器）
+	//SMS      alerts.SMSConfig      `yaml:"sms"`      // 匹配alert.sms
+}
+
+// AppConfig 全局配置（匹配YAML根层级）
+type AppConfig struct {
+	Monitor MonitorConfig              `yaml:"monitor"` // 匹配monitor根层级
+	Alert   AlertConfig                `yaml:"alert"`   // 匹配alert根层级
+	HTTP    server_config.HTTPConfig   `yaml:"http"`    // 匹配http根层级（内置HTTP服务）
+	Reload  server_config.ReloadConfig `yaml:"reload"`  // 匹配reload根层级（配置热加载）
+
+	refs      map[string]string // 通过环境变量/文件引用得到值的配置项（key=配置路径，value=原始引用，展示时替代实际值）
+	refIssues Issues            // 引用解析失败的问题（由validateConfig报告）
+}
+
+// defaultRateLimits 各渠道类型的默认发送限流
+// 钉钉自定义机器人每分钟最多20条，预留余量避免被限流封禁
+var defaultRateLimits = map[string]alert_config.RateLimitConfig{
+	"dingtalk": {PerMinute: 15, Burst: 5},
+	"email":    {PerMinute: 10, Burst: 10},
+}
+
+// receiverTypes 已注册的告警渠道类型（由告警注册器注册，校验receivers.type时使用）
+var receiverTypes = make(map[string]bool)
+
+// RegisterReceiverType 登记告警渠道类型（configs不能反向依赖告警实现，由告警注册器在注册时调用）
+func RegisterReceiverType(name string) {
+	receiverTypes[name] = true
+}
+
+// LoadConfig 加载并解析配置文件（校验不通过时仅记录警告，继续使用该配置）
+func LoadConfig(configPath string) (*AppConfig, error) {
+	log.Println("========== 开始加载配置文件 ==========")
+
+	cfg, root, _, err := parseConfig(configPath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. 校验配置合法性
+	if issues := validateConfig(cfg); len(issues) > 0 {
+		issues.locate(root)
+		for _, issue := range issues {
+			log.Printf("【警告】配置校验不通过：%s", issue)
+		}
+	}
+
+	log.Println("========== 配置文件加载完成 ==========")
+	return cfg, nil
+}
+
+// LoadValidConfig 加载配置文件并校验（存在任何问题时返回Issues错误，用于严格模式启动、热加载与配置检查）
+// strict为true时额外检查未知配置项（如拼写错误的cpu_treshold）并汇总所有类型错误，而不是遇到首个错误即停止
+func LoadValidConfig(configPath string, strict bool) (*AppConfig, error) {
+	cfg, root, issues, err := parseConfig(configPath, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	issues = append(issues, validateConfig(cfg)...)
+	if len(issues) > 0 {
+		issues.locate(root)
+		issues.sortByPosition()
+		return nil, issues
+	}
+	return cfg, nil
+}
+
+// parseConfig 读取、解析配置文件并填充默认值
+// 严格模式下类型错误与未知配置项作为Issues返回，非严格模式下类型错误直接返回error、未知配置项忽略
+func parseConfig(configPath string, strict bool) (*AppConfig, *yaml.Node, Issues, error) {
+	// 1. 处理配置文件路径
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("解析配置文件路径失败：%w", err)
+	}
+
+	// 2. 读取配置文件
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("读取配置文件失败：%w", err)
+	}
+
+	// 3. 解析YAML到结构体（先解析为节点树，保留行列号用于定位问题）
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, nil, fmt.Errorf("解析YAML配置失败：%w", err)
+	}
+	var cfg AppConfig
+	var issues Issues
+	if len(root.Content) > 0 {
+		if err := root.Decode(&cfg); err != nil {
+			var typeErr *yaml.TypeError
+			if !strict || !errors.As(err, &typeErr) {
+				return nil, nil, nil, fmt.Errorf("解析YAML配置失败：%w", err)
+			}
+			issues = append(issues, typeErrorIssues(typeErr, &root)...)
+		}
+		if strict {
+			issues = append(issues, checkKnownFields(&root, reflect.TypeOf(cfg), "")...)
+		}
+	}
+
+	// 4. 应用环境变量覆盖并解析 ${ENV} / file: 引用
+	cfg.refs = make(map[string]string)
+	applyEnvOverrides(&cfg)
+	resolveReferences(&cfg)
+
+	// 5. 填充默认值（适配新的独立间隔）
+	setDefaultConfig(&cfg)
+	return &cfg, &root, issues, nil
+}
+
+// setDefaultConfig 填充配置默认值（适配独立的采样间隔）
+func setDefaultConfig(cfg *AppConfig) {
+	// 服务器名称默认值
+	if cfg.Monitor.ServerName == "" {
+		cfg.Monitor.ServerName = fmt.Sprintf("sys-monitor-%s", pkg.GetOS())
+	}
+
+	// CPU配置默认值
+	if cfg.Monitor.CPU.Interval == 0 {
+		cfg.Monitor.CPU.Interval = pkg.Duration(30 * time.Second)
+	}
+	if cfg.Monitor.CPU.Threshold == 0 {
+		cfg.Monitor.CPU.Threshold = 80.0
+	}
+	if cfg.Monitor.CPU.CoreThreshold == 0 {
+		cfg.Monitor.CPU.CoreThreshold = 95.0
+	}
+	if cfg.Monitor.CPU.HotCoreCount == 0 {
+		cfg.Monitor.CPU.HotCoreCount = 1
+	}
+
+	// 内存配置默认值
+	if cfg.Monitor.Mem.Interval == 0 {
+		cfg.Monitor.Mem.Interval = pkg.Duration(30 * time.Second)
+	}
+	if cfg.Monitor.Mem.AvailableThreshold == 0 {
+		cfg.Monitor.Mem.AvailableThreshold = 2.0
+	}
+	for i := range cfg.Monitor.Mem.Rules {
+		if r := &cfg.Monitor.Mem.Rules[i]; r.Name == "" {
+			r.Name = "mem_" + r.Metric
+		}
+	}
+
+	// 磁盘配置默认值
+	if cfg.Monitor.Disk.Interval == 0 {
+		cfg.Monitor.Disk.Interval = pkg.Duration(60 * time.Second)
+	}
+	if cfg.Monitor.Disk.UsageThreshold == 0 {
+		cfg.Monitor.Disk.UsageThreshold = 85.0
+	}
+	if cfg.Monitor.Disk.InodeThreshold == 0 {
+		cfg.Monitor.Disk.InodeThreshold = 90.0
+	}
+	if len(cfg.Monitor.Disk.MonitorDisks) == 0 {
+		cfg.Monitor.Disk.MonitorDisks = pkg.GetDefaultDisks() // 自动识别系统磁盘
+	}
+
+	// Swap配置默认值（未配置swap_thresholds时按使用率判定，配置为{}表示只导出指标）
+	if cfg.Monitor.Swap.Interval == 0 {
+		cfg.Monitor.Swap.Interval = pkg.Duration(30 * time.Second)
+	}
+	if cfg.Monitor.Swap.Thresholds == nil {
+		cfg.Monitor.Swap.Thresholds = map[string]monitor_config.SwapThreshold{
+			"used_percent": {Warning: 80.0},
+		}
+	}
+
+	// 负载配置默认值（未配置load_thresholds时按每核5分钟负载判定，配置为{}表示只导出指标）
+	if cfg.Monitor.Load.Interval == 0 {
+		cfg.Monitor.Load.Interval = pkg.Duration(30 * time.Second)
+	}
+	if cfg.Monitor.Load.Thresholds == nil {
+		cfg.Monitor.Load.Thresholds = map[string]monitor_config.LoadThreshold{
+			"load5": {Warning: 2.0, PerCore: true},
+		}
+	}
+
+	// 告警通知默认值
+	if cfg.Alert.RepeatInterval == 0 {
+		cfg.Alert.RepeatInterval = pkg.Duration(time.Hour)
+	}
+
+	// 重试队列默认值
+	if cfg.Alert.Outbox.Path == "" {
+		cfg.Alert.Outbox.Path = "./data/alert_outbox.json"
+	}
+	if cfg.Alert.Outbox.MaxAge == 0 {
+		cfg.Alert.Outbox.MaxAge = pkg.Duration(24 * time.Hour)
+	}
+	if cfg.Alert.Outbox.InitialBackoff == 0 {
+		cfg.Alert.Outbox.InitialBackoff = pkg.Duration(30 * time.Second)
+	}
+	if cfg.Alert.Outbox.MaxBackoff == 0 {
+		cfg.Alert.Outbox.MaxBackoff = pkg.Duration(30 * time.Minute)
+	}
+
+	// 热加载默认值
+	if cfg.Reload.WatchInterval == 0 {
+		cfg.Reload.WatchInterval = pkg.Duration(10 * time.Second)
+	}
+
+	// 兼容旧配置：alert.dingtalk / alert.email 转为同名接收器
+	// 转换后清空旧配置块，避免dump-config与/api/v1/config输出两份（重新校验时接收器名称重复）
+	if cfg.Alert.DingTalk.Token != "" {
+		cfg.moveRefs("alert.dingtalk", fmt.Sprintf("alert.receivers[%d]", len(cfg.Alert.Receivers)))
+		cfg.Alert.Receivers = append(cfg.Alert.Receivers, alert_config.ReceiverConfig{
+			Name:           "dingtalk",
+			Type:           "dingtalk",
+			DingTalkConfig: cfg.Alert.DingTalk,
+		})
+		cfg.Alert.DingTalk = alert_config.DingTalkConfig{}
+	}
+	if cfg.Alert.Email.From != "" {
+		cfg.moveRefs("alert.email", fmt.Sprintf("alert.receivers[%d]", len(cfg.Alert.Receivers)))
+		cfg.Alert.Receivers = append(cfg.Alert.Receivers, alert_config.ReceiverConfig{
+			Name:        "email",
+			Type:        "email",
+			EmailConfig: cfg.Alert.Email,
+		})
+		cfg.Alert.Email = alert_config.EmailConfig{}
+	}
+
+	// 接收器名称默认同类型，限流未配置时使用渠道类型默认值
+	for i := range cfg.Alert.Receivers {
+		r := &cfg.Alert.Receivers[i]
+		if r.Name == "" {
+			r.Name = r.Type
+		}
+		if r.RateLimit.PerMinute == 0 {
+			r.RateLimit = defaultRateLimits[r.Type]
+		}
+		if r.RateLimit.PerMinute > 0 && r.RateLimit.Burst == 0 {
+			r.RateLimit.Burst = 1
+		}
+	}
+}
+
+// moveRefs 将配置项引用从旧路径迁移到新路径（旧配置转换为接收器后，脱敏输出仍展示原始引用而非实际值）
+func (cfg *AppConfig) moveRefs(from, to string) {
+	for path, ref := range cfg.refs {
+		if rest, ok := strings.CutPrefix(path, from+"."); ok {
+			cfg.refs[to+"."+rest] = ref
+			delete(cfg.refs, path)
+		}
+	}
+}
+
+// validateConfig 校验配置合法性（返回全部问题，路径对应config.yml中的配置项）
+func validateConfig(cfg *AppConfig) Issues {
+	// 环境变量/文件引用解析失败
+	issues := append(Issues(nil), cfg.refIssues...)
+
+	// CPU配置校验
+	if cfg.Monitor.CPU.Threshold < 0 || cfg.Monitor.CPU.Threshold > 100 {
+		issues.add("monitor.cpu_threshold", "CPU告警阈值必须在0-100之间")
+	}
+	if cfg.Monitor.CPU.CriticalThreshold != 0 &&
+		(cfg.Monitor.CPU.CriticalThreshold <= cfg.Monitor.CPU.Threshold || cfg.Monitor.CPU.CriticalThreshold > 100) {
+		issues.add("monitor.cpu_critical_threshold", "CPU严重阈值必须大于警告阈值且不超过100")
+	}
+	if cfg.Monitor.CPU.Interval.Duration() < 5*time.Second { // 最小间隔5秒，避免高频采样
+		issues.add("monitor.cpu_interval", "CPU采样间隔不能小于5秒")
+	}
+	if cfg.Monitor.CPU.For < 0 {
+		issues.add("monitor.cpu_for", "CPU告警持续时间不能为负数")
+	}
+	if cfg.Monitor.CPU.Hysteresis < 0 || cfg.Monitor.CPU.Hysteresis > cfg.Monitor.CPU.Threshold {
+		issues.add("monitor.cpu_hysteresis", "CPU恢复回差必须在0到告警阈值之间")
+	}
+	if cfg.Monitor.CPU.CoreThreshold < 0 || cfg.Monitor.CPU.CoreThreshold > 100 {
+		issues.add("monitor.cpu_core_threshold", "单核CPU告警阈值必须在0-100之间")
+	}
+	if cfg.Monitor.CPU.CoreCriticalThreshold != 0 &&
+		(cfg.Monitor.CPU.CoreCriticalThreshold <= cfg.Monitor.CPU.CoreThreshold || cfg.Monitor.CPU.CoreCriticalThreshold > 100) {
+		issues.add("monitor.cpu_core_critical_threshold", "单核CPU严重阈值必须大于警告阈值且不超过100")
+	}
+	if cfg.Monitor.CPU.HotCoreCount < 0 {
+		issues.add("monitor.cpu_hot_core_count", "CPU热点核心告警数不能为负数")
+	}
+	if cfg.Monitor.CPU.ImbalanceThreshold < 0 || cfg.Monitor.CPU.ImbalanceThreshold > 100 {
+		issues.add("monitor.cpu_imbalance_threshold", "CPU负载不均衡阈值必须在0-100之间")
+	}
+	validCPUTimeModes := map[string]bool{"user": true, "system": true, "iowait": true, "steal": true, "irq": true, "softirq": true}
+	for mode, t := range cfg.Monitor.CPU.TimeThresholds {
+		path := "monitor.cpu_time_thresholds." + mode
+		if !validCPUTimeModes[mode] {
+			issues.add(path, "未知的CPU时间分类: %s（可选 user/system/iowait/steal/irq/softirq）", mode)
+			continue
+		}
+		if t.Warning <= 0 || t.Warning > 100 {
+			issues.add(path+".warning", "CPU %s占比警告阈值必须在0-100之间（不含0）", mode)
+		}
+		if t.Critical != 0 && (t.Critical <= t.Warning || t.Critical > 100) {
+			issues.add(path+".critical", "CPU %s占比严重阈值必须大于警告阈值且不超过100", mode)
+		}
+	}
+
+	// 内存配置校验
+	if cfg.Monitor.Mem.AvailableThreshold < 0 {
+		issues.add("monitor.mem_available_threshold", "可用内存阈值不能为负数")
+	}
+	if cfg.Monitor.Mem.AvailableCriticalThreshold < 0 || cfg.Monitor.Mem.AvailableCriticalThreshold >= cfg.Monitor.Mem.AvailableThreshold {
+		issues.add("monitor.mem_available_critical_threshold", "可用内存严重阈值不能为负数且必须小于警告阈值")
+	}
+	if cfg.Monitor.Mem.Interval.Duration() < 5*time.Second {
+		issues.add("monitor.mem_interval", "内存采样间隔不能小于5秒")
+	}
+	if cfg.Monitor.Mem.For < 0 {
+		issues.add("monitor.mem_for", "内存告警持续时间不能为负数")
+	}
+	if cfg.Monitor.Mem.Hysteresis < 0 {
+		issues.add("monitor.mem_hysteresis", "内存恢复回差不能为负数")
+	}
+	memRuleNames := make(map[string]bool)
+	for i, r := range cfg.Monitor.Mem.Rules {
+		path := fmt.Sprintf("monitor.mem_rules[%d]", i)
+		issues = append(issues, validateMemRule(r, path)...)
+		if memRuleNames[r.Name] {
+			issues.add(path+".name", "内存规则名称重复: %s（同一指标配置多条规则时需指定不同的name）", r.Name)
+		}
+		memRuleNames[r.Name] = true
+	}
+
+	// 磁盘配置校验
+	if cfg.Monitor.Disk.UsageThreshold < 0 || cfg.Monitor.Disk.UsageThreshold > 100 {
+		issues.add("monitor.disk_usage_threshold", "磁盘使用率阈值必须在0-100之间")
+	}
+	if cfg.Monitor.Disk.UsageCriticalThreshold != 0 &&
+		(cfg.Monitor.Disk.UsageCriticalThreshold <= cfg.Monitor.Disk.UsageThreshold || cfg.Monitor.Disk.UsageCriticalThreshold > 100) {
+		issues.add("monitor.disk_usage_critical_threshold", "磁盘使用率严重阈值必须大于警告阈值且不超过100")
+	}
+	if cfg.Monitor.Disk.Interval.Duration() < 5*time.Second {
+		issues.add("monitor.disk_interval", "磁盘采样间隔不能小于5秒")
+	}
+	if cfg.Monitor.Disk.For < 0 {
+		issues.add("monitor.disk_for", "磁盘告警持续时间不能为负数")
+	}
+	if cfg.Monitor.Disk.Hysteresis < 0 || cfg.Monitor.Disk.Hysteresis > cfg.Monitor.Disk.UsageThreshold {
+		issues.add("monitor.disk_hysteresis", "磁盘恢复回差必须在0到告警阈值之间")
+	}
+	if cfg.Monitor.Disk.FreeThresholdGB < 0 {
+		issues.add("monitor.disk_free_threshold_gb", "磁盘剩余空间阈值不能为负数")
+	}
+	if cfg.Monitor.Disk.FreeCriticalThresholdGB != 0 &&
+		(cfg.Monitor.Disk.FreeCriticalThresholdGB < 0 || cfg.Monitor.Disk.FreeCriticalThresholdGB >= cfg.Monitor.Disk.FreeThresholdGB) {
+		issues.add("monitor.disk_free_critical_threshold_gb", "磁盘剩余空间严重阈值不能为负数且必须小于警告阈值")
+	}
+	if cfg.Monitor.Disk.FreeHysteresisGB < 0 {
+		issues.add("monitor.disk_free_hysteresis_gb", "磁盘剩余空间恢复回差不能为负数")
+	}
+	// inode阈值为负数表示禁用inode告警，此时不再校验严重阈值与回差
+	if cfg.Monitor.Disk.InodeThreshold > 100 {
+		issues.add("monitor.disk_inode_threshold", "inode使用率阈值不能超过100（负数表示禁用inode告警）")
+	}
+	if cfg.Monitor.Disk.InodeThreshold > 0 {
+		if cfg.Monitor.Disk.InodeCriticalThreshold != 0 &&
+			(cfg.Monitor.Disk.InodeCriticalThreshold <= cfg.Monitor.Disk.InodeThreshold || cfg.Monitor.Disk.InodeCriticalThreshold > 100) {
+			issues.add("monitor.disk_inode_critical_threshold", "inode使用率严重阈值必须大于警告阈值且不超过100")
+		}
+		if cfg.Monitor.Disk.InodeHysteresis < 0 || cfg.Monitor.Disk.InodeHysteresis > cfg.Monitor.Disk.InodeThreshold {
+			issues.add("monitor.disk_inode_hysteresis", "inode恢复回差必须在0到告警阈值之间")
+		}
+	}
+	mountPaths := make(map[string]bool)
+	for i, m := range cfg.Monitor.Disk.Mounts {
+		path := fmt.Sprintf("monitor.disk_mounts[%d]", i)
+		issues = append(issues, validateDiskMount(m, path)...)
+		if m.Path != "" && mountPaths[filepath.Clean(m.Path)] {
+			issues.add(path+".path", "分区覆盖配置重复: %s", m.Path)
+		}
+		mountPaths[filepath.Clean(m.Path)] = true
+	}
+
+	// Swap配置校验
+	if cfg.Monitor.Swap.Interval.Duration() < 5*time.Second {
+		issues.add("monitor.swap_interval", "Swap采样间隔不能小于5秒")
+	}
+	if cfg.Monitor.Swap.For < 0 {
+		issues.add("monitor.swap_for", "Swap告警持续时间不能为负数")
+	}
+	validSwapMetrics := map[string]bool{"used_percent": true, "used_gb": true, "in_rate": true, "out_rate": true}
+	for name, t := range cfg.Monitor.Swap.Thresholds {
+		path := "monitor.swap_thresholds." + name
+		if !validSwapMetrics[name] {
+			issues.add(path, "未知的Swap指标: %s（可选 used_percent/used_gb/in_rate/out_rate）", name)
+			continue
+		}
+		if t.Warning <= 0 || (name == "used_percent" && t.Warning > 100) {
+			issues.add(path+".warning", "Swap指标%s的警告阈值必须大于0（使用率不超过100）", name)
+		}
+		if t.Critical != 0 && (t.Critical <= t.Warning || (name == "used_percent" && t.Critical > 100)) {
+			issues.add(path+".critical", "Swap指标%s的严重阈值必须大于警告阈值（使用率不超过100）", name)
+		}
+		// 回差不小于警告阈值时恢复线不大于0，告警永远无法恢复
+		if t.Hysteresis < 0 || (t.Warning > 0 && t.Hysteresis >= t.Warning) {
+			issues.add(path+".hysteresis", "Swap指标%s的恢复回差必须不小于0且小于警告阈值", name)
+		}
+	}
+
+	// 负载配置校验
+	if cfg.Monitor.Load.Interval.Duration() < 5*time.Second {
+		issues.add("monitor.load_interval", "负载采样间隔不能小于5秒")
+	}
+	if cfg.Monitor.Load.For < 0 {
+		issues.add("monitor.load_for", "负载告警持续时间不能为负数")
+	}
+	validLoadMetrics := map[string]bool{"load1": true, "load5": true, "load15": true, "procs_running": true, "procs_blocked": true}
+	for name, t := range cfg.Monitor.Load.Thresholds {
+		path := "monitor.load_thresholds." + name
+		if !validLoadMetrics[name] {
+			issues.add(path, "未知的负载指标: %s（可选 load1/load5/load15/procs_running/procs_blocked）", name)
+			continue
+		}
+		if t.Warning <= 0 {
+			issues.add(path+".warning", "负载指标%s的警告阈值必须大于0", name)
+		}
+		if t.Critical != 0 && t.Critical <= t.Warning {
+			issues.add(path+".critical", "负载指标%s的严重阈值必须大于警告阈值", name)
+		}
+		// 回差不小于警告阈值时恢复线不大于0，告警永远无法恢复
+		if t.Hysteresis < 0 || (t.Warning > 0 && t.Hysteresis >= t.Warning) {
+			issues.add(path+".hysteresis", "负载指标%s的恢复回差必须不小于0且小于警告阈值", name)
+		}
+	}
+
+	// 告警通知校验
+	if cfg.Alert.RepeatInterval.Duration() < time.Minute {
+		issues.add("alert.repeat_interval", "重复告警间隔不能小于1分钟")
+	}
+
+	// 重试队列校验
+	if cfg.Alert.Outbox.InitialBackoff.Duration() < time.Second {
+		issues.add("alert.outbox.initial_backoff", "重试队列首次重试等待时间不能小于1秒")
+	}
+	if cfg.Alert.Outbox.MaxBackoff < cfg.Alert.Outbox.InitialBackoff {
+		issues.add("alert.outbox.max_backoff", "重试队列最大等待时间不能小于首次重试等待时间")
+	}
+	if cfg.Alert.Outbox.MaxAge < cfg.Alert.Outbox.InitialBackoff {
+		issues.add("alert.outbox.max_age", "重试队列最长保留时长不能小于首次重试等待时间")
+	}
+
+	// 热加载校验
+	if cfg.Reload.WatchInterval.Duration() < time.Second {
+		issues.add("reload.watch_interval", "配置文件变更检查间隔不能小于1秒")
+	}
+
+	// 告警接收器校验
+	receiverNames := make(map[string]bool)
+	for i, r := range cfg.Alert.Receivers {
+		path := fmt.Sprintf("alert.receivers[%d]", i)
+		issues = append(issues, validateReceiver(r, path)...)
+		if receiverNames[r.Name] {
+			issues.add(path+".name", "告警接收器名称重复: %s", r.Name)
+		}
+		receiverNames[r.Name] = true
+	}
+	issues = append(issues, validateRoute(cfg.Alert.Route, "alert.route", receiverNames)...)
+
+	return issues
+}
+
+// memRuleMetrics 内存规则支持的判定指标（value表示是否为"越低越危险"的可用内存类指标）
+var memRuleMetrics = map[string]bool{
+	"available_gb":         true,
+	"available_percent":    true,
+	"used_percent":         false,
+	"used_nocache_percent": false,
+}
+
+// validateMemRule 校验单条内存规则（可用内存类指标的严重阈值需小于警告阈值，使用率类指标需大于警告阈值）
+func validateMemRule(r monitor_config.MemRule, path string) Issues {
+	var issues Issues
+	below, ok := memRuleMetrics[r.Metric]
+	if !ok {
+		issues.add(path+".metric", "内存规则[%s]的判定指标未知: %s（可选 available_gb/available_percent/used_percent/used_nocache_percent）", r.Name, r.Metric)
+		return issues
+	}
+	percent := r.Metric != "available_gb"
+	if r.Warning <= 0 || (percent && r.Warning > 100) {
+		issues.add(path+".warning", "内存规则[%s]的警告阈值必须大于0（百分比不超过100）", r.Name)
+	}
+	if r.Critical != 0 {
+		switch {
+		case r.Critical < 0 || (percent && r.Critical > 100):
+			issues.add(path+".critical", "内存规则[%s]的严重阈值必须大于0（百分比不超过100）", r.Name)
+		case below && r.Critical >= r.Warning:
+			issues.add(path+".critical", "内存规则[%s]的严重阈值必须小于警告阈值（%s越低越危险）", r.Name, r.Metric)
+		case !below && r.Critical <= r.Warning:
+			issues.add(path+".critical", "内存规则[%s]的严重阈值必须大于警告阈值", r.Name)
+		}
+	}
+	// 恢复线需可达，否则告警永远无法恢复：可用类指标需回升到 警告阈值+回差，已用类指标需降到 警告阈值-回差
+	switch {
+	case r.Hysteresis < 0:
+		issues.add(path+".hysteresis", "内存规则[%s]的恢复回差不能为负数", r.Name)
+	case below && percent && r.Warning+r.Hysteresis > 100:
+		issues.add(path+".hysteresis", "内存规则[%s]的警告阈值与恢复回差之和不能超过100", r.Name)
+	case !below && r.Warning > 0 && r.Hysteresis >= r.Warning:
+		issues.add(path+".hysteresis", "内存规则[%s]的恢复回差必须小于警告阈值", r.Name)
+	}
+	if r.For < 0 {
+		issues.add(path+".for", "内存规则[%s]的告警持续时间不能为负数", r.Name)
+	}
+	return issues
+}
+
+// validateDiskMount 校验单个分区覆盖配置（阈值为0表示不覆盖，严重阈值需配合警告阈值使用）
+func validateDiskMount(m monitor_config.DiskMountConfig, path string) Issues {
+	var issues Issues
+	if m.Path == "" {
+		issues.add(path+".path", "分区覆盖配置未指定path")
+	}
+	if m.UsageThreshold < 0 || m.UsageThreshold > 100 {
+		issues.add(path+".usage_threshold", "分区[%s]使用率阈值必须在0-100之间", m.Path)
+	}
+	if m.UsageCriticalThreshold != 0 &&
+		(m.UsageThreshold == 0 || m.UsageCriticalThreshold <= m.UsageThreshold || m.UsageCriticalThreshold > 100) {
+		issues.add(path+".usage_critical_threshold", "分区[%s]使用率严重阈值需配置警告阈值，且必须大于警告阈值、不超过100", m.Path)
+	}
+	if m.FreeThresholdGB < 0 {
+		issues.add(path+".free_threshold_gb", "分区[%s]剩余空间阈值不能为负数", m.Path)
+	}
+	if m.FreeCriticalThresholdGB != 0 &&
+		(m.FreeCriticalThresholdGB < 0 || m.FreeCriticalThresholdGB >= m.FreeThresholdGB) {
+		issues.add(path+".free_critical_threshold_gb", "分区[%s]剩余空间严重阈值需配置警告阈值，且不能为负数、必须小于警告阈值", m.Path)
+	}
+	if m.InodeThreshold > 100 {
+		issues.add(path+".inode_threshold", "分区[%s]inode使用率阈值不能超过100（负数表示禁用该分区inode告警）", m.Path)
+	}
+	if m.InodeCriticalThreshold != 0 &&
+		(m.InodeThreshold <= 0 || m.InodeCriticalThreshold <= m.InodeThreshold || m.InodeCriticalThreshold > 100) {
+		issues.add(path+".inode_critical_threshold", "分区[%s]inode使用率严重阈值需配置警告阈值，且必须大于警告阈值、不超过100", m.Path)
+	}
+	if m.Interval != 0 && m.Interval.Duration() < 5*time.Second {
+		issues.add(path+".interval", "分区[%s]采样间隔不能小于5秒", m.Path)
+	}
+	if m.For < 0 {
+		issues.add(path+".for", "分区[%s]告警持续时间不能为负数", m.Path)
+	}
+	return issues
+}
+
+// validateReceiver 校验单个告警接收器（按渠道类型校验专属字段）
+func validateReceiver(r alert_config.ReceiverConfig, path string) Issues {
+	var issues Issues
+	if r.Type == "" {
+		issues.add(path+".type", "告警接收器[%s]未配置type", r.Name)
+	} else if !receiverTypes[r.Type] {
+		types := make([]string, 0, len(receiverTypes))
+		for t := range receiverTypes {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		issues.add(path+".type", "告警接收器[%s]的类型[%s]未注册（可选 %s）", r.Name, r.Type, strings.Join(types, "/"))
+	}
+	validSeverities := map[string]bool{"": true, "warning": true, "critical": true}
+	if !validSeverities[r.MinSeverity] {
+		issues.add(path+".min_severity", "告警接收器[%s]的告警级别配置只能为warning或critical", r.Name)
+	}
+	if !validSeverities[r.AtAllSeverity] {
+		issues.add(path+".at_all_severity", "告警接收器[%s]的告警级别配置只能为warning或critical", r.Name)
+	}
+	if r.RateLimit.PerMinute > 0 && r.RateLimit.Burst < 0 {
+		issues.add(path+".rate_limit.burst", "告警接收器[%s]的rate_limit.burst不能为负数", r.Name)
+	}
+
+	switch r.Type {
+	case "dingtalk":
+		if r.Token != "" && r.Secret == "" {
+			issues.add(path+".secret", "告警接收器[%s]配置了钉钉Token但未配置Secret", r.Name)
+		}
+	case "email":
+		if r.From != "" {
+			if r.SmtpHost == "" {
+				issues.add(path+".smtp_host", "告警接收器[%s]配置了发件人邮箱但未配置SMTP服务器", r.Name)
+			}
+			if r.SmtpPort == 0 {
+				issues.add(path+".smtp_port", "告警接收器[%s]配置了发件人邮箱但未配置SMTP端口", r.Name)
+			}
+		}
+	}
+	return issues
+}
+
+// validateRoute 递归校验告警路由引用的接收器是否存在
+func validateRoute(route alert_config.RouteConfig, path string, knownReceivers map[string]bool) Issues {
+	var issues Issues
+	for _, name := range route.Receivers {
+		if !knownReceivers[name] {
+			issues.add(path+".receivers", "引用了未知的告警接收器: %s", name)
+		}
+	}
+	for i, child := range route.Routes {
+		issues = append(issues, validateRoute(child, fmt.Sprintf("%s.routes[%d]", path, i), knownReceivers)...)
+	}
+	return issues
+}
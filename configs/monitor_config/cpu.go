@@ -1,10 +1,26 @@
-// configs/monitor_config/cpu.go
-package monitor_config
-
-import "time"
-
-// CPUConfig CPU监控配置
-type CPUConfig struct {
-	Interval  time.Duration `yaml:"cpu_interval"`  // CPU采样间隔（秒）
-	Threshold float64       `yaml:"cpu_threshold"` // CPU告警阈值（%）
-}
+// configs/monitor_config/cpu.go
+package monitor_config
+
+import "github.com/Jwunai/sys-monitor-service/pkg"
+
+// CPUConfig CPU监控配置
+type CPUConfig struct {
+	Interval              pkg.Duration `yaml:"cpu_interval"`                // CPU采样间隔（如"30s"，纯数字按秒）
+	Threshold             float64      `yaml:"cpu_threshold"`               // CPU警告阈值（%）
+	CriticalThreshold     float64      `yaml:"cpu_critical_threshold"`      // CPU严重阈值（%，0表示不启用严重级别）
+	For                   pkg.Duration `yaml:"cpu_for"`                     // 持续超阈值多久才触发告警（0表示立即告警）
+	Hysteresis            float64      `yaml:"cpu_hysteresis"`              // 恢复回差（%），使用率降到 阈值-回差 以下才视为恢复
+	PerCore               bool         `yaml:"cpu_per_core"`                // 启用单核监控（热点核心与负载不均衡检测）
+	CoreThreshold         float64      `yaml:"cpu_core_threshold"`          // 单核使用率警告阈值（%）
+	CoreCriticalThreshold float64      `yaml:"cpu_core_critical_threshold"` // 单核使用率严重阈值（%，0表示不启用严重级别）
+	HotCoreCount          int          `yaml:"cpu_hot_core_count"`          // 超过单核阈值的核心数达到该值时告警（持续时间同cpu_for）
+	ImbalanceThreshold    float64      `yaml:"cpu_imbalance_threshold"`     // 负载不均衡阈值（最高核心与平均使用率之差，百分点，0表示不检测）
+
+	TimeThresholds map[string]CPUTimeThreshold `yaml:"cpu_time_thresholds"` // 各类CPU时间占比阈值（key为user/system/iowait/steal/irq/softirq，未配置的分类只导出指标）
+}
+
+// CPUTimeThreshold 单类CPU时间占比阈值
+type CPUTimeThreshold struct {
+	Warning  float64 `yaml:"warning"`  // 警告阈值（%）
+	Critical float64 `yaml:"critical"` // 严重阈值（%，0表示不启用严重级别）
+}
@@ -1,11 +1,41 @@
-// configs/monitor_config/disk.go
-package monitor_config
-
-import "time"
-
-// DiskConfig 磁盘监控配置
-type DiskConfig struct {
-	Interval       time.Duration `yaml:"disk_interval"`        // 磁盘采样间隔（秒）
-	UsageThreshold float64       `yaml:"disk_usage_threshold"` // 磁盘使用率阈值（%）
-	MonitorDisks   []string      `yaml:"monitor_disks"`        // 监控磁盘分区（空数组自动监控所有）
-}
+// configs/monitor_config/disk.go
+package monitor_config
+
+import "github.com/Jwunai/sys-monitor-service/pkg"
+
+// DiskConfig 磁盘监控配置
+type DiskConfig struct {
+	Interval                pkg.Duration      `yaml:"disk_interval"`                   // 磁盘采样间隔（如"30s"，纯数字按秒）
+	UsageThreshold          float64           `yaml:"disk_usage_threshold"`            // 磁盘使用率警告阈值（%）
+	UsageCriticalThreshold  float64           `yaml:"disk_usage_critical_threshold"`   // 磁盘使用率严重阈值（%，0表示不启用严重级别）
+	FreeThresholdGB         float64           `yaml:"disk_free_threshold_gb"`          // 剩余空间警告阈值（GB，0表示不按剩余空间判定；与使用率阈值同时配置时两者均超过才告警）
+	FreeCriticalThresholdGB float64           `yaml:"disk_free_critical_threshold_gb"` // 剩余空间严重阈值（GB，0表示不启用严重级别）
+	MonitorDisks            []string          `yaml:"monitor_disks"`                   // 监控磁盘分区（空数组自动监控所有）
+	For                     pkg.Duration      `yaml:"disk_for"`                        // 持续超阈值多久才触发告警（按分区独立计时，0表示立即告警）
+	Hysteresis              float64           `yaml:"disk_hysteresis"`                 // 恢复回差（%），使用率降到 阈值-回差 以下才视为恢复
+	FreeHysteresisGB        float64           `yaml:"disk_free_hysteresis_gb"`         // 剩余空间恢复回差（GB），剩余空间回升到 阈值+回差 以上才视为恢复
+	InodeThreshold          float64           `yaml:"disk_inode_threshold"`            // inode使用率警告阈值（%），独立于空间使用率判定（负数表示禁用inode告警）
+	InodeCriticalThreshold  float64           `yaml:"disk_inode_critical_threshold"`   // inode使用率严重阈值（%，0表示不启用严重级别）
+	InodeHysteresis         float64           `yaml:"disk_inode_hysteresis"`           // inode恢复回差（%）
+	Mounts                  []DiskMountConfig `yaml:"disk_mounts"`                     // 按分区覆盖的配置（未覆盖的分区使用以上全局配置）
+}
+
+// DiskMountConfig 单个分区的覆盖配置
+// 配置了任一空间阈值（使用率或剩余空间）时，该分区只使用此处配置的空间阈值，不再继承全局空间阈值；inode阈值单独覆盖
+type DiskMountConfig struct {
+	Path                    string       `yaml:"path"`                       // 分区挂载点（需在监控分区列表中）
+	Disabled                bool         `yaml:"disabled"`                   // 禁用该分区的告警规则（仍采集指标）
+	Interval                pkg.Duration `yaml:"interval"`                   // 该分区的采样间隔（0表示沿用disk_interval）
+	UsageThreshold          float64      `yaml:"usage_threshold"`            // 使用率警告阈值（%）
+	UsageCriticalThreshold  float64      `yaml:"usage_critical_threshold"`   // 使用率严重阈值（%）
+	FreeThresholdGB         float64      `yaml:"free_threshold_gb"`          // 剩余空间警告阈值（GB）
+	FreeCriticalThresholdGB float64      `yaml:"free_critical_threshold_gb"` // 剩余空间严重阈值（GB）
+	InodeThreshold          float64      `yaml:"inode_threshold"`            // inode使用率警告阈值（%，0表示沿用全局，负数表示禁用该分区inode告警）
+	InodeCriticalThreshold  float64      `yaml:"inode_critical_threshold"`   // inode使用率严重阈值（%）
+	For                     pkg.Duration `yaml:"for"`                        // 持续超阈值多久才触发告警（0表示沿用disk_for）
+}
+
+// HasThresholds 判断是否配置了分区专属的空间阈值
+func (m DiskMountConfig) HasThresholds() bool {
+	return m.UsageThreshold != 0 || m.UsageCriticalThreshold != 0 || m.FreeThresholdGB != 0 || m.FreeCriticalThresholdGB != 0
+}
@@ -0,0 +1,19 @@
+// configs/monitor_config/load.go
+package monitor_config
+
+import "github.com/Jwunai/sys-monitor-service/pkg"
+
+// LoadConfig 系统负载监控配置
+type LoadConfig struct {
+	Interval   pkg.Duration             `yaml:"load_interval"`   // 负载采样间隔（如"30s"，纯数字按秒）
+	Thresholds map[string]LoadThreshold `yaml:"load_thresholds"` // 各负载指标阈值（key为load1/load5/load15/procs_running/procs_blocked，未配置的只导出指标）
+	For        pkg.Duration             `yaml:"load_for"`        // 持续超阈值多久才触发告警（0表示立即告警）
+}
+
+// LoadThreshold 单个负载指标的阈值
+type LoadThreshold struct {
+	Warning    float64 `yaml:"warning"`    // 警告阈值
+	Critical   float64 `yaml:"critical"`   // 严重阈值（0表示不启用严重级别）
+	PerCore    bool    `yaml:"per_core"`   // 阈值按每个CPU核心计算（如load1/核心数 > 2），适配核心数不同的机器
+	Hysteresis float64 `yaml:"hysteresis"` // 恢复回差（与阈值同单位，per_core时为每核数值），降到 警告阈值-回差 以下才视为恢复
+}
@@ -1,10 +1,24 @@
-// configs/monitor_config/mem.go
-package monitor_config
-
-import "time"
-
-// MemConfig 内存监控专属配置
-type MemConfig struct {
-	Interval           time.Duration `yaml:"mem_interval"`            // 内存采样间隔（秒）
-	AvailableThreshold float64       `yaml:"mem_available_threshold"` // 可用内存告警阈值（GB）
-}
+// configs/monitor_config/mem.go
+package monitor_config
+
+import "github.com/Jwunai/sys-monitor-service/pkg"
+
+// MemConfig 内存监控专属配置
+type MemConfig struct {
+	Interval                   pkg.Duration `yaml:"mem_interval"`                     // 内存采样间隔（如"30s"，纯数字按秒）
+	AvailableThreshold         float64      `yaml:"mem_available_threshold"`          // 可用内存警告阈值（GB）
+	AvailableCriticalThreshold float64      `yaml:"mem_available_critical_threshold"` // 可用内存严重阈值（GB，需小于警告阈值，0表示不启用）
+	For                        pkg.Duration `yaml:"mem_for"`                          // 持续低于阈值多久才触发告警（0表示立即告警）
+	Hysteresis                 float64      `yaml:"mem_hysteresis"`                   // 恢复回差（GB），可用内存升到 阈值+回差 以上才视为恢复
+	Rules                      []MemRule    `yaml:"mem_rules"`                        // 内存告警规则列表（配置后忽略以上可用内存阈值，可同时配置多条）
+}
+
+// MemRule 单条内存告警规则
+type MemRule struct {
+	Name       string       `yaml:"name"`       // 规则名（默认"mem_"+metric，同一指标配置多条时需区分）
+	Metric     string       `yaml:"metric"`     // 判定指标：available_gb/available_percent/used_percent/used_nocache_percent
+	Warning    float64      `yaml:"warning"`    // 警告阈值（available_*低于阈值告警，used_*高于阈值告警）
+	Critical   float64      `yaml:"critical"`   // 严重阈值（0表示不启用严重级别）
+	Hysteresis float64      `yaml:"hysteresis"` // 恢复回差（与阈值同单位）
+	For        pkg.Duration `yaml:"for"`        // 持续超阈值多久才触发告警（0表示沿用mem_for）
+}
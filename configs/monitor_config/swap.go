@@ -0,0 +1,18 @@
+// configs/monitor_config/swap.go
+package monitor_config
+
+import "github.com/Jwunai/sys-monitor-service/pkg"
+
+// SwapConfig Swap监控配置
+type SwapConfig struct {
+	Interval   pkg.Duration             `yaml:"swap_interval"`   // Swap采样间隔（如"30s"，纯数字按秒）
+	Thresholds map[string]SwapThreshold `yaml:"swap_thresholds"` // 各Swap指标阈值（key为used_percent/used_gb/in_rate/out_rate，未配置的只导出指标）
+	For        pkg.Duration             `yaml:"swap_for"`        // 持续超阈值多久才触发告警（0表示立即告警）
+}
+
+// SwapThreshold 单个Swap指标的阈值
+type SwapThreshold struct {
+	Warning    float64 `yaml:"warning"`    // 警告阈值（used_percent为%，used_gb为GB，in_rate/out_rate为MB/s）
+	Critical   float64 `yaml:"critical"`   // 严重阈值（0表示不启用严重级别）
+	Hysteresis float64 `yaml:"hysteresis"` // 恢复回差（与阈值同单位），降到 警告阈值-回差 以下才视为恢复
+}
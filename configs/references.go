@@ -0,0 +1,97 @@
+// configs/references.go
+package configs
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix 环境变量覆盖前缀
+const envPrefix = "SYSMON"
+
+// filePrefix 从文件读取配置值的前缀（如"file:/run/secrets/dingtalk_token"）
+const filePrefix = "file:"
+
+// envRefPattern 匹配配置值中的环境变量引用（如"${DINGTALK_TOKEN}"）
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// applyEnvOverrides 按环境变量覆盖配置项（变量名为 SYSMON_ + 配置路径大写，如 SYSMON_ALERT_EMAIL_PASSWORD）
+// 日志只记录被覆盖的配置项与变量名，不输出变量值
+func applyEnvOverrides(cfg *AppConfig) {
+	walkFields(reflect.ValueOf(cfg).Elem(), "", envPrefix, func(path, envName string, field reflect.Value, _ reflect.StructTag) {
+		value, ok := os.LookupEnv(envName)
+		if !ok {
+			return
+		}
+		if field.Kind() == reflect.String {
+			field.SetString(value)
+		} else if err := yaml.Unmarshal([]byte(value), field.Addr().Interface()); err != nil {
+			cfg.refIssues.add(path, "环境变量%s的值无法解析为该配置项的类型", envName)
+			return
+		}
+		cfg.refs[path] = "${" + envName + "}"
+		log.Printf("配置项[%s]已由环境变量%s覆盖", path, envName)
+	})
+}
+
+// resolveReferences 解析所有字符串配置项中的 ${ENV} 与 file:/path 引用
+// 解析失败的引用记录为配置问题（由validateConfig报告），原始引用文本保留在refs中用于脱敏展示
+func resolveReferences(cfg *AppConfig) {
+	walkFields(reflect.ValueOf(cfg).Elem(), "", envPrefix, func(path, _ string, field reflect.Value, _ reflect.StructTag) {
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(cfg.resolve(path, field.String()))
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.String {
+				return
+			}
+			for i := 0; i < field.Len(); i++ {
+				elem := field.Index(i)
+				elem.SetString(cfg.resolve(fmt.Sprintf("%s[%d]", path, i), elem.String()))
+			}
+		case reflect.Map:
+			if field.Type().Elem().Kind() != reflect.String {
+				return
+			}
+			iter := field.MapRange()
+			for iter.Next() {
+				resolved := cfg.resolve(joinPath(path, iter.Key().String()), iter.Value().String())
+				field.SetMapIndex(iter.Key(), reflect.ValueOf(resolved))
+			}
+		}
+	})
+}
+
+// resolve 解析单个配置值中的引用（无引用时原样返回）
+func (cfg *AppConfig) resolve(path, value string) string {
+	if strings.HasPrefix(value, filePrefix) {
+		file := strings.TrimPrefix(value, filePrefix)
+		data, err := os.ReadFile(file)
+		if err != nil {
+			cfg.refIssues.add(path, "读取引用文件失败: %s", file)
+			return ""
+		}
+		cfg.refs[path] = value
+		return strings.TrimRight(string(data), "\r\n")
+	}
+
+	if !envRefPattern.MatchString(value) {
+		return value
+	}
+	resolved := envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envRefPattern.FindStringSubmatch(ref)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			cfg.refIssues.add(path, "引用的环境变量未设置: %s", name)
+		}
+		return v
+	})
+	cfg.refs[path] = value
+	return resolved
+}
@@ -0,0 +1,7 @@
+// configs/server_config/http.go
+package server_config
+
+// HTTPConfig 内置HTTP服务配置（Prometheus指标、JSON状态接口）
+type HTTPConfig struct {
+	Listen string `yaml:"listen"` // 监听地址（如":9105"，为空表示不启动HTTP服务）
+}
@@ -0,0 +1,10 @@
+// configs/server_config/reload.go
+package server_config
+
+import "github.com/Jwunai/sys-monitor-service/pkg"
+
+// ReloadConfig 配置热加载（SIGHUP信号始终触发重新加载，此处配置是否额外监听文件变更）
+type ReloadConfig struct {
+	Watch         bool         `yaml:"watch"`          // 是否检测配置文件变更并自动重新加载
+	WatchInterval pkg.Duration `yaml:"watch_interval"` // 配置文件变更检查间隔
+}
@@ -0,0 +1,71 @@
+// configs/walk.go
+package configs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldVisitor 配置项访问函数
+// path为配置项路径（如"alert.receivers[0].token"），envName为对应的环境变量覆盖名（如"SYSMON_ALERT_RECEIVERS_ONCALL_TOKEN"）
+type fieldVisitor func(path, envName string, field reflect.Value, tag reflect.StructTag)
+
+// walkFields 递归遍历配置结构体的所有叶子配置项（展开inline内嵌结构体与结构体列表）
+func walkFields(v reflect.Value, path, envName string, visit fieldVisitor) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		field := v.Field(i)
+		if strings.Contains(opts, "inline") {
+			walkFields(field, path, envName, visit)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		childPath := joinPath(path, name)
+		childEnv := envName + "_" + envSegment(name)
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			walkFields(field, childPath, childEnv, visit)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < field.Len(); j++ {
+				elem := field.Index(j)
+				walkFields(elem, fmt.Sprintf("%s[%d]", childPath, j), childEnv+"_"+elementEnvSegment(elem, j), visit)
+			}
+		default:
+			visit(childPath, childEnv, field, f.Tag)
+		}
+	}
+}
+
+// elementEnvSegment 列表元素在环境变量名中的标识（有name字段时使用名称，否则使用下标）
+func elementEnvSegment(elem reflect.Value, index int) string {
+	if name := elem.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String && name.String() != "" {
+		return envSegment(name.String())
+	}
+	return fmt.Sprint(index)
+}
+
+// envSegment 转换为环境变量名片段（大写，非字母数字替换为下划线，如"oncall-dingtalk"→"ONCALL_DINGTALK"）
+func envSegment(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
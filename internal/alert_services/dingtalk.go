@@ -1,102 +1,154 @@
-// internal/alert_services/dingtalk.go
-package alert_services
-
-import (
-	"bytes"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"strconv"
-	"time"
-
-	"github.com/Jwunai/sys-monitor-service/configs/alert_config"
-	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
-)
-
-// DingTalk 钉钉告警实现
-type DingTalk struct {
-	cfg *alert_config.DingTalkConfig // 钉钉配置
-}
-
-// NewDingTalk 创建钉钉告警实例
-func NewDingTalk(cfg *alert_config.DingTalkConfig) interfaces.AlertSender {
-	return &DingTalk{cfg: cfg}
-}
-
-// Name 返回告警渠道名称
-func (d *DingTalk) Name() string {
-	return "钉钉"
-}
-
-// IsEnabled 判断是否启用（token和secret非空）
-func (d *DingTalk) IsEnabled() bool {
-	return d.cfg != nil && d.cfg.Token != "" && d.cfg.Secret != ""
-}
-
-// SendAlert 发送钉钉告警（支持签名验证）
-func (d *DingTalk) SendAlert(title, serverName, content string) error {
-	if !d.IsEnabled() {
-		return fmt.Errorf("钉钉告警未启用配置缺失")
-	}
-
-	// 1. 构造钉钉webhook地址
-	webhookURL := fmt.Sprintf("https://oapi.dingtalk.com/robot/send?access_token=%s", d.cfg.Token)
-
-	// 2. 计算签名（如果配置了secret）
-	if d.cfg.Secret != "" {
-		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
-		stringToSign := fmt.Sprintf("%s\n%s", timestamp, d.cfg.Secret)
-
-		// HMAC-SHA256加密
-		h := hmac.New(sha256.New, []byte(d.cfg.Secret))
-		h.Write([]byte(stringToSign))
-		sign := url.QueryEscape(base64.StdEncoding.EncodeToString(h.Sum(nil)))
-
-		// 拼接签名到URL
-		webhookURL = fmt.Sprintf("%s&timestamp=%s&sign=%s", webhookURL, timestamp, sign)
-	}
-
-	// 3. 构造告警消息体
-	msg := map[string]interface{}{
-		"msgtype": "text",
-		"text": map[string]string{
-			"content": fmt.Sprintf("[%s] %s\n%s", serverName, title, content),
-		},
-	}
-	jsonData, err := json.Marshal(msg)
-	if err != nil {
-		return fmt.Errorf("构造钉钉消息失败: %w", err)
-	}
-
-	// 4. 发送HTTP请求
-	resp, err := http.Post(webhookURL, "application/json", io.NopCloser(bytes.NewBuffer(jsonData)))
-	if err != nil {
-		return fmt.Errorf("发送钉钉告警请求失败: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// 5. 解析响应
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("读取钉钉响应失败: %w", err)
-	}
-
-	// 钉钉响应格式：{"errcode":0,"errmsg":"ok"}
-	var result map[string]interface{}
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return fmt.Errorf("解析钉钉响应失败: %w, 响应内容: %s", err, string(respBody))
-	}
-
-	if errcode, ok := result["errcode"].(float64); ok && errcode != 0 {
-		return fmt.Errorf("钉钉告警发送失败: %s (errcode: %.0f)", result["errmsg"], errcode)
-	}
-
-	return nil
-}
-
-var _ interfaces.AlertSender = (*DingTalk)(nil)
+// internal/alert_services/dingtalk.go
+package alert_services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// DingTalk 钉钉告警实现
+type DingTalk struct {
+	cfg *alert_config.ReceiverConfig // 接收器配置（钉钉专属字段内嵌于其中）
+}
+
+// NewDingTalk 创建钉钉告警实例
+func NewDingTalk(cfg *alert_config.ReceiverConfig) interfaces.AlertSender {
+	return &DingTalk{cfg: cfg}
+}
+
+// Name 返回告警接收器名称（配置中的name，区分同类型的多个实例）
+func (d *DingTalk) Name() string {
+	return d.cfg.Name
+}
+
+// IsEnabled 判断是否启用（token和secret非空）
+func (d *DingTalk) IsEnabled() bool {
+	return d.cfg != nil && d.cfg.Token != "" && d.cfg.Secret != ""
+}
+
+// AcceptSeverity 判断是否接收该级别告警（低于min_severity的告警不发送）
+func (d *DingTalk) AcceptSeverity(sev interfaces.Severity) bool {
+	minSev, _ := interfaces.ParseSeverity(d.cfg.MinSeverity)
+	return sev >= minSev
+}
+
+// SendAlert 发送钉钉告警（支持签名验证，达到at_all_severity级别时@所有人）
+// 返回的错误已脱敏，不包含token、secret及webhook地址中的签名
+func (d *DingTalk) SendAlert(alert interfaces.Alert) error {
+	return redactError(d.send(alert), d.cfg.Token, d.cfg.Secret)
+}
+
+// send 构造并发送钉钉消息
+func (d *DingTalk) send(alert interfaces.Alert) error {
+	if !d.IsEnabled() {
+		return fmt.Errorf("钉钉告警未启用配置缺失")
+	}
+
+	// 1. 构造钉钉webhook地址
+	webhookURL := fmt.Sprintf("https://oapi.dingtalk.com/robot/send?access_token=%s", d.cfg.Token)
+
+	// 2. 计算签名（如果配置了secret）
+	if d.cfg.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		stringToSign := fmt.Sprintf("%s\n%s", timestamp, d.cfg.Secret)
+
+		// HMAC-SHA256加密
+		h := hmac.New(sha256.New, []byte(d.cfg.Secret))
+		h.Write([]byte(stringToSign))
+		sign := url.QueryEscape(base64.StdEncoding.EncodeToString(h.Sum(nil)))
+
+		// 拼接签名到URL
+		webhookURL = fmt.Sprintf("%s&timestamp=%s&sign=%s", webhookURL, timestamp, sign)
+	}
+
+	// 3. 构造告警消息体
+	atAllSev, _ := interfaces.ParseSeverity(d.cfg.AtAllSeverity)
+	msg := map[string]interface{}{
+		"msgtype": "markdown",
+		"markdown": map[string]string{
+			"title": fmt.Sprintf("[%s] %s", alert.Host.ServerName, alert.Title),
+			"text":  renderDingTalkMarkdown(alert),
+		},
+		"at": map[string]bool{
+			"isAtAll": atAllSev != interfaces.SeverityNone && alert.State == interfaces.AlertFiring && alert.Severity >= atAllSev,
+		},
+	}
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("构造钉钉消息失败: %w", err)
+	}
+
+	// 4. 发送HTTP请求
+	resp, err := http.Post(webhookURL, "application/json", io.NopCloser(bytes.NewBuffer(jsonData)))
+	if err != nil {
+		return fmt.Errorf("发送钉钉告警请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 5. 解析响应
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取钉钉响应失败: %w", err)
+	}
+
+	// 钉钉响应格式：{"errcode":0,"errmsg":"ok"}
+	var result map[string]interface{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("解析钉钉响应失败: %w, 响应内容: %s", err, string(respBody))
+	}
+
+	if errcode, ok := result["errcode"].(float64); ok && errcode != 0 {
+		return fmt.Errorf("钉钉告警发送失败: %s (errcode: %.0f)", result["errmsg"], errcode)
+	}
+
+	return nil
+}
+
+// renderDingTalkMarkdown 按告警字段渲染钉钉markdown正文
+func renderDingTalkMarkdown(alert interfaces.Alert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### [%s] %s\n\n", alert.Host.ServerName, alert.Title)
+	if len(alert.Grouped) > 0 {
+		// 限流合并的汇总告警：逐条列出明细
+		fmt.Fprintf(&b, "- **最高级别**：%s\n", alert.Severity.Label())
+		fmt.Fprintf(&b, "- **主机**：%s（%s）\n\n", alert.Host.Hostname, alert.Host.OS)
+		fmt.Fprintf(&b, "%s：\n\n", alert.Description)
+		for i, a := range alert.Grouped {
+			fmt.Fprintf(&b, "%d. %s | %s | 当前值：%s | 阈值：%s | %s\n",
+				i+1, a.Title, a.Resource, a.FormatValue(a.Value), a.FormatValue(a.Threshold), a.StartsAt.Format("15:04:05"))
+		}
+		return b.String()
+	}
+	fmt.Fprintf(&b, "- **状态**：%s\n", alert.State.Label())
+	fmt.Fprintf(&b, "- **级别**：%s\n", alert.Severity.Label())
+	fmt.Fprintf(&b, "- **资源**：%s\n", alert.Resource)
+	fmt.Fprintf(&b, "- **当前值**：%s\n", alert.FormatValue(alert.Value))
+	fmt.Fprintf(&b, "- **阈值**：%s\n", alert.FormatValue(alert.Threshold))
+	fmt.Fprintf(&b, "- **开始时间**：%s\n", alert.StartsAt.Format("2006-01-02 15:04:05"))
+	if alert.State == interfaces.AlertResolved {
+		fmt.Fprintf(&b, "- **恢复时间**：%s\n", alert.EndsAt.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Fprintf(&b, "- **持续时间**：%v\n", alert.Duration())
+	fmt.Fprintf(&b, "- **主机**：%s（%s）\n", alert.Host.Hostname, alert.Host.OS)
+	if alert.Description != "" {
+		// markdown中单个换行不生效，使用两个空格+换行
+		fmt.Fprintf(&b, "\n%s\n", strings.ReplaceAll(alert.Description, "\n", "  \n"))
+	}
+	return b.String()
+}
+
+var _ interfaces.AlertSender = (*DingTalk)(nil)
+var _ interfaces.SeverityFilter = (*DingTalk)(nil)
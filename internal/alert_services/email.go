@@ -1,92 +1,164 @@
-// internal/alert_services/email.go
-package alert_services
-
-import (
-	"bytes"
-	"fmt"
-	"net/smtp"
-	"strings"
-	"time"
-
-	"github.com/Jwunai/sys-monitor-service/configs/alert_config" // 替换为实际module名
-	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
-)
-
-// Email 邮箱告警实现
-type Email struct {
-	cfg *alert_config.EmailConfig // 邮箱配置
-}
-
-// NewEmail 创建邮箱告警实例
-func NewEmail(cfg *alert_config.EmailConfig) interfaces.AlertSender {
-	return &Email{cfg: cfg}
-}
-
-// Name 返回告警渠道名称
-func (e *Email) Name() string {
-	return "邮箱"
-}
-
-// IsEnabled 判断是否启用（发件人、SMTP服务器、端口非空）
-func (e *Email) IsEnabled() bool {
-	return e.cfg != nil && e.cfg.From != "" && e.cfg.SmtpHost != "" && e.cfg.SmtpPort != 0 && len(e.cfg.To) > 0
-}
-
-// SendAlert 发送邮箱告警
-func (e *Email) SendAlert(title, serverName, content string) error {
-	if !e.IsEnabled() {
-		return fmt.Errorf("邮箱告警未启用配置缺失")
-	}
-
-	// 1. 构造邮件内容
-	subject := fmt.Sprintf("【%s】%s", serverName, title)
-	emailContent := fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>%s</title>
-</head>
-<body>
-    <div style="font-family: Arial, sans-serif; font-size: 14px; line-height: 1.6;">
-        <p>%s</p>
-        <p>告警时间：%s</p>
-    </div>
-</body>
-</html>
-`, subject, content, time.Now().Format("2006-01-02 15:04:05"))
-
-	// 2. 构造SMTP认证信息
-	auth := smtp.PlainAuth(
-		"",
-		e.cfg.From,
-		e.cfg.Password,
-		e.cfg.SmtpHost,
-	)
-
-	// 3. 构造邮件头
-	var msg bytes.Buffer
-	msg.WriteString(fmt.Sprintf("From: %s\r\n", e.cfg.From))
-	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(e.cfg.To, ",")))
-	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
-	msg.WriteString("MIME-Version: 1.0\r\n")
-	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
-	msg.WriteString(emailContent)
-
-	// 4. 发送邮件
-	smtpAddr := fmt.Sprintf("%s:%d", e.cfg.SmtpHost, e.cfg.SmtpPort)
-	err := smtp.SendMail(
-		smtpAddr,
-		auth,
-		e.cfg.From,
-		e.cfg.To,
-		msg.Bytes(),
-	)
-	if err != nil {
-		return fmt.Errorf("发送邮箱告警失败: %w", err)
-	}
-
-	return nil
-}
-
-var _ interfaces.AlertSender = (*Email)(nil)
+// internal/alert_services/email.go
+package alert_services
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config" // 替换为实际module名
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// Email 邮箱告警实现
+type Email struct {
+	cfg *alert_config.ReceiverConfig // 接收器配置（邮箱专属字段内嵌于其中）
+}
+
+// NewEmail 创建邮箱告警实例
+func NewEmail(cfg *alert_config.ReceiverConfig) interfaces.AlertSender {
+	return &Email{cfg: cfg}
+}
+
+// Name 返回告警接收器名称（配置中的name，区分同类型的多个实例）
+func (e *Email) Name() string {
+	return e.cfg.Name
+}
+
+// IsEnabled 判断是否启用（发件人、SMTP服务器、端口非空）
+func (e *Email) IsEnabled() bool {
+	return e.cfg != nil && e.cfg.From != "" && e.cfg.SmtpHost != "" && e.cfg.SmtpPort != 0 && len(e.cfg.To) > 0
+}
+
+// AcceptSeverity 判断是否接收该级别告警（低于min_severity的告警不发送）
+func (e *Email) AcceptSeverity(sev interfaces.Severity) bool {
+	minSev, _ := interfaces.ParseSeverity(e.cfg.MinSeverity)
+	return sev >= minSev
+}
+
+// SendAlert 发送邮箱告警（返回的错误已脱敏，不包含邮箱授权码）
+func (e *Email) SendAlert(alert interfaces.Alert) error {
+	return redactError(e.send(alert), e.cfg.Password)
+}
+
+// send 构造并发送邮件
+func (e *Email) send(alert interfaces.Alert) error {
+	if !e.IsEnabled() {
+		return fmt.Errorf("邮箱告警未启用配置缺失")
+	}
+
+	// 1. 构造邮件内容
+	subject := fmt.Sprintf("【%s】%s", alert.Host.ServerName, alert.Title)
+	emailContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+</head>
+<body>
+    <div style="font-family: Arial, sans-serif; font-size: 14px; line-height: 1.6;">
+        %s
+        <p>%s</p>
+        <p>告警时间：%s</p>
+    </div>
+</body>
+</html>
+`, html.EscapeString(subject), renderEmailTable(alert),
+		strings.ReplaceAll(html.EscapeString(alert.Description), "\n", "<br>"),
+		time.Now().Format("2006-01-02 15:04:05"))
+
+	// 2. 构造SMTP认证信息
+	auth := smtp.PlainAuth(
+		"",
+		e.cfg.From,
+		e.cfg.Password,
+		e.cfg.SmtpHost,
+	)
+
+	// 3. 构造邮件头
+	var msg bytes.Buffer
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", e.cfg.From))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(e.cfg.To, ",")))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	msg.WriteString(emailContent)
+
+	// 4. 发送邮件
+	smtpAddr := fmt.Sprintf("%s:%d", e.cfg.SmtpHost, e.cfg.SmtpPort)
+	err := smtp.SendMail(
+		smtpAddr,
+		auth,
+		e.cfg.From,
+		e.cfg.To,
+		msg.Bytes(),
+	)
+	if err != nil {
+		return fmt.Errorf("发送邮箱告警失败: %w", err)
+	}
+
+	return nil
+}
+
+// renderEmailTable 按告警字段渲染邮件中的告警信息表格
+func renderEmailTable(alert interfaces.Alert) string {
+	if len(alert.Grouped) > 0 {
+		return renderEmailGroupedTable(alert.Grouped)
+	}
+
+	rows := [][2]string{
+		{"状态", alert.State.Label()},
+		{"级别", alert.Severity.Label()},
+		{"资源", alert.Resource},
+		{"当前值", alert.FormatValue(alert.Value)},
+		{"阈值", alert.FormatValue(alert.Threshold)},
+		{"开始时间", alert.StartsAt.Format("2006-01-02 15:04:05")},
+	}
+	if alert.State == interfaces.AlertResolved {
+		rows = append(rows, [2]string{"恢复时间", alert.EndsAt.Format("2006-01-02 15:04:05")})
+	}
+	rows = append(rows,
+		[2]string{"持续时间", alert.Duration().String()},
+		[2]string{"主机", fmt.Sprintf("%s（%s）", alert.Host.Hostname, alert.Host.OS)},
+	)
+
+	var b strings.Builder
+	b.WriteString(`<table border="1" cellspacing="0" cellpadding="4" style="border-collapse: collapse;">`)
+	for _, row := range rows {
+		fmt.Fprintf(&b, "<tr><th align=\"left\">%s</th><td>%s</td></tr>", row[0], html.EscapeString(row[1]))
+	}
+	b.WriteString("</table>")
+	return b.String()
+}
+
+// renderEmailGroupedTable 渲染限流合并的汇总告警明细表格（每条告警一行）
+func renderEmailGroupedTable(alerts []interfaces.Alert) string {
+	var b strings.Builder
+	b.WriteString(`<table border="1" cellspacing="0" cellpadding="4" style="border-collapse: collapse;">`)
+	b.WriteString("<tr><th>告警</th><th>状态</th><th>级别</th><th>资源</th><th>当前值</th><th>阈值</th><th>开始时间</th></tr>")
+	for _, a := range alerts {
+		cells := []string{
+			a.Title,
+			a.State.Label(),
+			a.Severity.Label(),
+			a.Resource,
+			a.FormatValue(a.Value),
+			a.FormatValue(a.Threshold),
+			a.StartsAt.Format("2006-01-02 15:04:05"),
+		}
+		b.WriteString("<tr>")
+		for _, c := range cells {
+			fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(c))
+		}
+		b.WriteString("</tr>")
+	}
+	b.WriteString("</table>")
+	return b.String()
+}
+
+var _ interfaces.AlertSender = (*Email)(nil)
+var _ interfaces.SeverityFilter = (*Email)(nil)
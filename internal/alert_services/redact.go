@@ -0,0 +1,50 @@
+// internal/alert_services/redact.go
+package alert_services
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/Jwunai/sys-monitor-service/pkg"
+)
+
+// redactError 返回脱敏后的错误（告警渠道的错误会写入日志与重试队列，不能包含token、secret、密码）
+// *url.Error中的请求地址携带access_token、timestamp、sign等查询参数，一并脱敏
+func redactError(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+			for _, values := range u.Query() {
+				secrets = append(secrets, values...)
+			}
+		}
+	}
+	msg := redactSecrets(err.Error(), secrets...)
+	if msg == err.Error() {
+		return err
+	}
+	return errors.New(msg)
+}
+
+// redactSecrets 将文本中出现的密钥替换为脱敏形式（同时处理URL编码后的形式）
+func redactSecrets(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		masked := pkg.Desensitize(secret)
+		if masked == secret {
+			// 过短无法部分保留的密钥整体替换
+			masked = "***"
+		}
+		s = strings.ReplaceAll(s, secret, masked)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			s = strings.ReplaceAll(s, escaped, masked)
+		}
+	}
+	return s
+}
@@ -0,0 +1,189 @@
+// internal/alert_services/redact_test.go
+package alert_services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/internal/monitor"
+	"github.com/Jwunai/sys-monitor-service/internal/outbox"
+	"github.com/Jwunai/sys-monitor-service/pkg"
+)
+
+const (
+	testToken    = "ae8042c730b88ce55acced5dde21e9bd4c7e1f2a"
+	testSecret   = "SEC9f1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9"
+	testPassword = "smtpAuthCode2024"
+)
+
+// assertNoSecrets 断言文本中不包含任何密钥（原始形式及URL编码形式）
+func assertNoSecrets(t *testing.T, text string, secrets ...string) {
+	t.Helper()
+	for _, secret := range secrets {
+		if strings.Contains(text, secret) {
+			t.Errorf("输出中包含密钥%q:\n%s", secret, text)
+		}
+		if escaped := url.QueryEscape(secret); strings.Contains(text, escaped) {
+			t.Errorf("输出中包含URL编码后的密钥%q:\n%s", escaped, text)
+		}
+	}
+}
+
+func TestRedactErrorURLQuery(t *testing.T) {
+	timestamp := "1792290000000"
+	sign := "k3Jq+9vX/2mZ8bW1yR7tL0pQ4sN6uE5hC3aD8fG2iJ0="
+	webhookURL := fmt.Sprintf(
+		"https://oapi.dingtalk.com/robot/send?access_token=%s&timestamp=%s&sign=%s",
+		testToken, timestamp, url.QueryEscape(sign),
+	)
+	err := fmt.Errorf("发送钉钉告警请求失败: %w", &url.Error{
+		Op:  "Post",
+		URL: webhookURL,
+		Err: errors.New("dial tcp: lookup oapi.dingtalk.com: no such host"),
+	})
+
+	msg := redactError(err, testToken, testSecret).Error()
+	assertNoSecrets(t, msg, testToken, testSecret, timestamp, sign)
+	if !strings.Contains(msg, "no such host") {
+		t.Errorf("脱敏后丢失了原始错误原因: %s", msg)
+	}
+}
+
+func TestRedactErrorSecretLengths(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		secret string
+	}{
+		{"邮箱授权码", fmt.Errorf("535 authentication failed: user=ops password=%s", testPassword), testPassword},
+		{"2位密钥", errors.New("invalid secret: k9"), "k9"},
+		{"10位密钥", errors.New("invalid secret: abcd1234ef"), "abcd1234ef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := redactError(tt.err, tt.secret).Error()
+			assertNoSecrets(t, msg, tt.secret)
+			if !strings.Contains(msg, pkg.Desensitize(tt.secret)) {
+				t.Errorf("未按Desensitize规则脱敏: %s", msg)
+			}
+		})
+	}
+
+	if err := redactError(nil, testPassword); err != nil {
+		t.Errorf("nil错误应原样返回，实际: %v", err)
+	}
+}
+
+// firingCollector 每次采样都返回超过严重阈值的结果，用于触发告警发送
+type firingCollector struct{}
+
+func (firingCollector) Name() string { return "测试" }
+
+func (firingCollector) Interval() time.Duration { return 10 * time.Millisecond }
+
+func (firingCollector) Collect() ([]interfaces.Sample, error) {
+	return []interfaces.Sample{{
+		Rule:      "test_rule",
+		Resource:  "test",
+		Value:     99,
+		Unit:      "%",
+		Severity:  interfaces.SeverityCritical,
+		Threshold: 90,
+		Title:     "测试告警",
+		Content:   "测试告警内容",
+	}}, nil
+}
+
+// roundTripFunc 替换默认HTTP传输层，避免测试访问外网
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestDeliverFailureLogRedacted(t *testing.T) {
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("dial tcp: lookup %s: no such host", req.URL.Host)
+	})
+	t.Cleanup(func() { http.DefaultTransport = oldTransport })
+
+	// 占用一个端口后立即关闭，SMTP连接必然被拒绝
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("分配端口失败: %v", err)
+	}
+	smtpPort := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	ding := &alert_config.ReceiverConfig{
+		Name:           "dingtalk",
+		Type:           "dingtalk",
+		DingTalkConfig: alert_config.DingTalkConfig{Token: testToken, Secret: testSecret},
+	}
+	email := &alert_config.ReceiverConfig{
+		Name: "email",
+		Type: "email",
+		EmailConfig: alert_config.EmailConfig{
+			From:     "ops@example.com",
+			Password: testPassword,
+			SmtpHost: "127.0.0.1",
+			SmtpPort: smtpPort,
+			To:       []string{"oncall@example.com"},
+		},
+	}
+	queuePath := filepath.Join(t.TempDir(), "outbox.json")
+	queue, err := outbox.Open(alert_config.OutboxConfig{
+		Path:           queuePath,
+		MaxAge:         pkg.Duration(time.Hour),
+		InitialBackoff: pkg.Duration(time.Hour),
+		MaxBackoff:     pkg.Duration(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("打开重试队列失败: %v", err)
+	}
+
+	mgr := monitor.NewManager(
+		"test-server",
+		[]interfaces.Collector{firingCollector{}},
+		map[string]interfaces.AlertSender{ding.Name: NewDingTalk(ding), email.Name: NewEmail(email)},
+		configs.AlertConfig{Receivers: []alert_config.ReceiverConfig{*ding, *email}},
+		queue,
+	)
+	mgr.Start()
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.Stats().Depth < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	mgr.Stop()
+
+	if depth := queue.Stats().Depth; depth != 2 {
+		t.Fatalf("期望两个接收器发送失败后入队，实际队列长度: %d\n%s", depth, buf.String())
+	}
+	output := buf.String()
+	if !strings.Contains(output, "发送失败") {
+		t.Fatalf("未捕获到发送失败日志:\n%s", output)
+	}
+	assertNoSecrets(t, output, testToken, testSecret, testPassword)
+
+	data, err := os.ReadFile(queuePath)
+	if err != nil {
+		t.Fatalf("读取重试队列文件失败: %v", err)
+	}
+	assertNoSecrets(t, string(data), testToken, testSecret, testPassword)
+}
@@ -0,0 +1,70 @@
+// internal/api/metrics.go
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// handleMetrics 以Prometheus文本格式输出全部指标（统一附加server_name标签）
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeMetrics(w, s.mgr.Metrics(), s.mgr.Host().ServerName)
+}
+
+// writeMetrics 按指标名分组输出（同名指标的HELP/TYPE只输出一次）
+func writeMetrics(w io.Writer, metrics []interfaces.Metric, serverName string) {
+	var names []string
+	groups := make(map[string][]interfaces.Metric)
+	for _, m := range metrics {
+		if _, ok := groups[m.Name]; !ok {
+			names = append(names, m.Name)
+		}
+		groups[m.Name] = append(groups[m.Name], m)
+	}
+
+	for _, name := range names {
+		group := groups[name]
+		fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(group[0].Help))
+		fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+		for _, m := range group {
+			fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(m.Labels, serverName), strconv.FormatFloat(m.Value, 'g', -1, 64))
+		}
+	}
+}
+
+// formatLabels 渲染标签集合（按标签名排序，保证输出稳定）
+func formatLabels(labels map[string]string, serverName string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys)+1)
+	pairs = append(pairs, fmt.Sprintf(`server_name="%s"`, escapeLabelValue(serverName)))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, k, escapeLabelValue(labels[k])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labelValueEscaper 标签值转义（反斜杠、双引号、换行）
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// helpEscaper HELP文本转义（反斜杠、换行）
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
+
+func escapeHelp(v string) string {
+	return helpEscaper.Replace(v)
+}
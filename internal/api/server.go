@@ -0,0 +1,69 @@
+// internal/api/server.go
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/monitor"
+)
+
+// Server 内置HTTP服务（Prometheus指标导出、JSON状态查询）
+type Server struct {
+	srv       *http.Server
+	cfg       atomic.Pointer[configs.AppConfig] // 生效配置（仅用于脱敏展示，热加载时更新）
+	mgr       *monitor.Manager                  // 监控管理器（提供最近采样与告警状态）
+	startedAt time.Time                         // 服务启动时间
+}
+
+// NewServer 创建HTTP服务（http.listen为空时返回nil）
+func NewServer(cfg *configs.AppConfig, mgr *monitor.Manager) *Server {
+	if cfg.HTTP.Listen == "" {
+		return nil
+	}
+
+	s := &Server{mgr: mgr, startedAt: time.Now()}
+	s.cfg.Store(cfg)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("GET /api/v1/status", s.handleStatus)
+	mux.HandleFunc("GET /api/v1/collectors", s.handleCollectors)
+	mux.HandleFunc("GET /api/v1/alerts", s.handleAlerts)
+	mux.HandleFunc("GET /api/v1/receivers", s.handleReceivers)
+	mux.HandleFunc("GET /api/v1/config", s.handleConfig)
+	s.srv = &http.Server{
+		Addr:              cfg.HTTP.Listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// SetConfig 更新生效配置（热加载后调用）
+func (s *Server) SetConfig(cfg *configs.AppConfig) {
+	s.cfg.Store(cfg)
+}
+
+// Start 后台启动HTTP服务
+func (s *Server) Start() {
+	go func() {
+		log.Printf("HTTP服务已启动 | 监听地址: %s | 指标: /metrics | 状态: /api/v1/status", s.srv.Addr)
+		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("❌ HTTP服务异常退出: %v", err)
+		}
+	}()
+}
+
+// Stop 优雅关闭HTTP服务
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.srv.Shutdown(ctx); err != nil {
+		log.Printf("HTTP服务关闭失败: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+// internal/api/status.go
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/internal/monitor"
+	"github.com/Jwunai/sys-monitor-service/internal/outbox"
+)
+
+// StatusResponse 运行状态汇总（/api/v1/status 响应）
+type StatusResponse struct {
+	Host       interfaces.HostInfo       `json:"host"`
+	StartedAt  time.Time                 `json:"started_at"`
+	Uptime     string                    `json:"uptime"`
+	Collectors []monitor.CollectorStatus `json:"collectors"`
+	Alerts     []monitor.AlertStatus     `json:"alerts"`
+	Receivers  []string                  `json:"receivers"`
+	Outbox     *outbox.Stats             `json:"outbox,omitempty"` // 未启用重试队列时省略
+}
+
+// handleStatus 返回运行状态汇总（采样、告警、接收器、重试队列）
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	resp := StatusResponse{
+		Host:       s.mgr.Host(),
+		StartedAt:  s.startedAt,
+		Uptime:     time.Since(s.startedAt).Round(time.Second).String(),
+		Collectors: s.mgr.Collectors(),
+		Alerts:     s.mgr.ActiveAlerts(),
+		Receivers:  s.mgr.Receivers(),
+	}
+	if stats, ok := s.mgr.OutboxStats(); ok {
+		resp.Outbox = &stats
+	}
+	writeJSON(w, resp)
+}
+
+// handleCollectors 返回各采集器最近一次采样结果
+func (s *Server) handleCollectors(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.mgr.Collectors())
+}
+
+// handleAlerts 返回待触发与告警中的告警
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.mgr.ActiveAlerts())
+}
+
+// handleReceivers 返回启用的告警接收器
+func (s *Server) handleReceivers(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.mgr.Receivers())
+}
+
+// handleConfig 返回脱敏后的生效配置（字段名与config.yml一致）
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	out, err := s.cfg.Load().DesensitizedMap()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, out)
+}
+
+// writeJSON 输出JSON响应
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Printf("HTTP响应输出失败: %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+// internal/collectors/cpu.go
+package collectors
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/monitor_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// CPU CPU使用率采集器
+type CPU struct {
+	cfg       monitor_config.CPUConfig // CPU专属配置
+	lastTimes *cpu.TimesStat           // 上次采样的累计CPU时间（用于计算各类时间占比）
+}
+
+// NewCPU 创建CPU采集器
+func NewCPU(cfg monitor_config.CPUConfig) interfaces.Collector {
+	return &CPU{cfg: cfg}
+}
+
+// Name 返回采集器名称
+func (c *CPU) Name() string {
+	return "CPU"
+}
+
+// Interval 返回采样间隔
+func (c *CPU) Interval() time.Duration {
+	return c.cfg.Interval.Duration()
+}
+
+// Rules 返回当前配置下的全部规则名
+func (c *CPU) Rules() []string {
+	rules := []string{"cpu_usage"}
+	for _, mode := range cpuTimeModes {
+		if _, ok := c.cfg.TimeThresholds[mode]; ok {
+			rules = append(rules, "cpu_"+mode)
+		}
+	}
+	if c.cfg.PerCore {
+		rules = append(rules, "cpu_hot_cores")
+		if c.cfg.ImbalanceThreshold > 0 {
+			rules = append(rules, "cpu_imbalance")
+		}
+	}
+	return rules
+}
+
+// Collect 采集CPU使用率
+func (c *CPU) Collect() ([]interfaces.Sample, error) {
+	usageList, err := cpu.Percent(0, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(usageList) == 0 {
+		return nil, fmt.Errorf("未获取到使用率数据")
+	}
+	cpuUsage := usageList[0]
+
+	breakdown, err := c.sampleCPUTimes()
+	if err != nil {
+		log.Printf("CPU时间分类采集失败: %v", err)
+	}
+
+	log.Printf(
+		"CPU状态 | 使用率: %.2f%% | 警告阈值: %.2f%% | 严重阈值: %.2f%%",
+		cpuUsage, c.cfg.Threshold, c.cfg.CriticalThreshold,
+	)
+
+	level := evaluateAbove(cpuUsage, c.cfg.Threshold, c.cfg.CriticalThreshold, c.cfg.Hysteresis)
+	content := fmt.Sprintf(
+		"CPU使用率超标！\n告警级别: %s\n当前使用率: %.2f%%\n告警阈值: %.2f%%",
+		level.severity.Label(), cpuUsage, level.threshold,
+	)
+	metrics := []interfaces.Metric{
+		{Name: "sysmon_cpu_usage_percent", Help: "CPU使用率（%）", Value: cpuUsage},
+	}
+	if breakdown != nil {
+		log.Printf("CPU时间分类 | %s", strings.ReplaceAll(breakdown.describe(), "\n", " | "))
+		content += "\n" + breakdown.describe()
+		metrics = append(metrics, breakdown.metrics()...)
+	}
+	samples := []interfaces.Sample{{
+		Rule:      "cpu_usage",
+		Resource:  "CPU",
+		Labels:    map[string]string{"resource": "cpu"},
+		Value:     cpuUsage,
+		Unit:      "%",
+		Severity:  level.severity,
+		Threshold: level.threshold,
+		Cleared:   level.cleared,
+		For:       c.cfg.For.Duration(),
+		Title:     "CPU告警",
+		Content:   content,
+		Metrics:   metrics,
+	}}
+	if breakdown != nil {
+		samples = append(samples, c.timeSamples(breakdown, cpuUsage)...)
+	}
+
+	if !c.cfg.PerCore {
+		return samples, nil
+	}
+	coreSamples, err := c.collectCores()
+	if err != nil {
+		log.Printf("单核CPU使用率采集失败: %v", err)
+		return samples, nil
+	}
+	return append(samples, coreSamples...), nil
+}
+
+// collectCores 采集各核心使用率，生成热点核心与负载不均衡两条规则
+// 热点核心：超过单核阈值的核心数达到cpu_hot_core_count即告警（单线程进程占满单核时整体使用率可能很低）
+// 负载不均衡：最高核心使用率与平均使用率之差超过cpu_imbalance_threshold即告警
+func (c *CPU) collectCores() ([]interfaces.Sample, error) {
+	cores, err := cpu.Percent(0, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(cores) == 0 {
+		return nil, fmt.Errorf("未获取到单核使用率数据")
+	}
+
+	var sum, maxUsage float64
+	var hot, hotCritical, notCleared int
+	metrics := make([]interfaces.Metric, 0, len(cores)+3)
+	for i, usage := range cores {
+		sum += usage
+		maxUsage = max(maxUsage, usage)
+		if usage > c.cfg.CoreThreshold {
+			hot++
+		}
+		if c.cfg.CoreCriticalThreshold > 0 && usage > c.cfg.CoreCriticalThreshold {
+			hotCritical++
+		}
+		if usage > c.cfg.CoreThreshold-c.cfg.Hysteresis {
+			notCleared++
+		}
+		metrics = append(metrics, interfaces.Metric{
+			Name:   "sysmon_cpu_core_usage_percent",
+			Help:   "单核CPU使用率（%）",
+			Labels: map[string]string{"core": strconv.Itoa(i)},
+			Value:  usage,
+		})
+	}
+	mean := sum / float64(len(cores))
+	imbalance := maxUsage - mean
+	metrics = append(metrics,
+		interfaces.Metric{Name: "sysmon_cpu_hot_cores", Help: "超过单核阈值的核心数", Value: float64(hot)},
+		interfaces.Metric{Name: "sysmon_cpu_core_imbalance_percent", Help: "最高核心与平均使用率之差（百分点）", Value: imbalance},
+	)
+
+	log.Printf(
+		"单核CPU状态 | 核心数: %d | 最高: %.2f%% | 平均: %.2f%% | 超过单核阈值(%.2f%%)的核心数: %d | 告警核心数: %d",
+		len(cores), maxUsage, mean, c.cfg.CoreThreshold, hot, c.cfg.HotCoreCount,
+	)
+
+	n := c.cfg.HotCoreCount
+	hotLevel := levelResult{threshold: float64(n), cleared: notCleared < n}
+	switch {
+	case c.cfg.CoreCriticalThreshold > 0 && hotCritical >= n:
+		hotLevel.severity = interfaces.SeverityCritical
+	case hot >= n:
+		hotLevel.severity = interfaces.SeverityWarning
+	}
+	coreList := formatCoreUsages(cores)
+	samples := []interfaces.Sample{{
+		Rule:      "cpu_hot_cores",
+		Resource:  "CPU",
+		Labels:    map[string]string{"resource": "cpu"},
+		Value:     float64(hot),
+		Unit:      "核",
+		Severity:  hotLevel.severity,
+		Threshold: hotLevel.threshold,
+		Cleared:   hotLevel.cleared,
+		For:       c.cfg.For.Duration(),
+		Title:     "CPU热点核心告警",
+		Content: fmt.Sprintf(
+			"%d个核心使用率超过%.2f%%（告警核心数: %d）！\n告警级别: %s\n整体使用率: %.2f%%\n最高核心: %.2f%%\n各核心使用率（从高到低）:\n%s",
+			hot, c.cfg.CoreThreshold, n, hotLevel.severity.Label(), mean, maxUsage, coreList,
+		),
+		Metrics: metrics,
+	}}
+
+	if c.cfg.ImbalanceThreshold > 0 {
+		level := evaluateAbove(imbalance, c.cfg.ImbalanceThreshold, 0, c.cfg.Hysteresis)
+		samples = append(samples, interfaces.Sample{
+			Rule:      "cpu_imbalance",
+			Resource:  "CPU",
+			Labels:    map[string]string{"resource": "cpu"},
+			Value:     imbalance,
+			Unit:      "%",
+			Severity:  level.severity,
+			Threshold: level.threshold,
+			Cleared:   level.cleared,
+			For:       c.cfg.For.Duration(),
+			Title:     "CPU负载不均衡告警",
+			Content: fmt.Sprintf(
+				"CPU负载不均衡！\n告警级别: %s\n最高核心: %.2f%%\n平均使用率: %.2f%%\n差值: %.2f个百分点（阈值: %.2f）\n各核心使用率（从高到低）:\n%s",
+				level.severity.Label(), maxUsage, mean, imbalance, level.threshold, coreList,
+			),
+		})
+	}
+	return samples, nil
+}
+
+// formatCoreUsages 按使用率从高到低列出各核心（每行4个）
+func formatCoreUsages(cores []float64) string {
+	order := make([]int, len(cores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return cores[order[a]] > cores[order[b]] })
+
+	var b strings.Builder
+	for i, core := range order {
+		switch {
+		case i == 0:
+		case i%4 == 0:
+			b.WriteString("\n")
+		default:
+			b.WriteString(" | ")
+		}
+		fmt.Fprintf(&b, "cpu%d: %.2f%%", core, cores[core])
+	}
+	return b.String()
+}
+
+var _ interfaces.Collector = (*CPU)(nil)
+var _ interfaces.RuleLister = (*CPU)(nil)
@@ -0,0 +1,128 @@
+// internal/collectors/cpu_times.go
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// cpuTimeModes CPU时间分类（顺序即日志、告警内容与规则的输出顺序）
+var cpuTimeModes = []string{"user", "system", "iowait", "steal", "irq", "softirq"}
+
+// cpuTimeModeLabels CPU时间分类的中文名称
+var cpuTimeModeLabels = map[string]string{
+	"user":    "用户态",
+	"system":  "内核态",
+	"iowait":  "IO等待",
+	"steal":   "虚拟化争抢(steal)",
+	"irq":     "硬中断",
+	"softirq": "软中断",
+}
+
+// cpuBreakdown 两次采样之间各类CPU时间的占比（%）
+type cpuBreakdown map[string]float64
+
+// sampleCPUTimes 读取累计CPU时间，与上次采样求差得到各类时间占比（首次采样无上次数据时返回nil）
+func (c *CPU) sampleCPUTimes() (cpuBreakdown, error) {
+	times, err := cpu.Times(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("未获取到CPU时间数据")
+	}
+	current := times[0]
+	last := c.lastTimes
+	c.lastTimes = &current
+	if last == nil {
+		return nil, nil
+	}
+
+	total := cpuTimesTotal(current) - cpuTimesTotal(*last)
+	if total <= 0 {
+		return nil, nil
+	}
+	delta := func(cur, prev float64) float64 {
+		return max(cur-prev, 0) / total * 100
+	}
+	return cpuBreakdown{
+		"user":    delta(current.User, last.User),
+		"system":  delta(current.System, last.System),
+		"iowait":  delta(current.Iowait, last.Iowait),
+		"steal":   delta(current.Steal, last.Steal),
+		"irq":     delta(current.Irq, last.Irq),
+		"softirq": delta(current.Softirq, last.Softirq),
+	}, nil
+}
+
+// cpuTimesTotal 累计CPU总时间（Linux下guest时间已计入user，不重复累加）
+func cpuTimesTotal(t cpu.TimesStat) float64 {
+	return t.User + t.Nice + t.System + t.Idle + t.Iowait + t.Irq + t.Softirq + t.Steal
+}
+
+// dominant 返回占比最高的时间分类
+func (b cpuBreakdown) dominant() string {
+	var top string
+	for _, mode := range cpuTimeModes {
+		if top == "" || b[mode] > b[top] {
+			top = mode
+		}
+	}
+	return top
+}
+
+// describe 返回主要占用与各类时间占比描述（用于日志与告警内容）
+func (b cpuBreakdown) describe() string {
+	parts := make([]string, 0, len(cpuTimeModes))
+	for _, mode := range cpuTimeModes {
+		parts = append(parts, fmt.Sprintf("%s %.2f%%", mode, b[mode]))
+	}
+	top := b.dominant()
+	return fmt.Sprintf("主要占用: %s %.2f%%\n各类时间占比: %s", cpuTimeModeLabels[top], b[top], strings.Join(parts, " | "))
+}
+
+// metrics 各类时间占比指标
+func (b cpuBreakdown) metrics() []interfaces.Metric {
+	metrics := make([]interfaces.Metric, 0, len(cpuTimeModes))
+	for _, mode := range cpuTimeModes {
+		metrics = append(metrics, interfaces.Metric{
+			Name:   "sysmon_cpu_time_percent",
+			Help:   "两次采样之间各类CPU时间占比（%）",
+			Labels: map[string]string{"mode": mode},
+			Value:  b[mode],
+		})
+	}
+	return metrics
+}
+
+// timeSamples 按配置的各类时间阈值生成规则（如iowait、steal），规则名为"cpu_"+分类
+func (c *CPU) timeSamples(b cpuBreakdown, usage float64) []interfaces.Sample {
+	var samples []interfaces.Sample
+	for _, mode := range cpuTimeModes {
+		threshold, ok := c.cfg.TimeThresholds[mode]
+		if !ok {
+			continue
+		}
+		level := evaluateAbove(b[mode], threshold.Warning, threshold.Critical, c.cfg.Hysteresis)
+		samples = append(samples, interfaces.Sample{
+			Rule:      "cpu_" + mode,
+			Resource:  "CPU",
+			Labels:    map[string]string{"resource": "cpu", "mode": mode},
+			Value:     b[mode],
+			Unit:      "%",
+			Severity:  level.severity,
+			Threshold: level.threshold,
+			Cleared:   level.cleared,
+			For:       c.cfg.For.Duration(),
+			Title:     fmt.Sprintf("CPU %s告警", cpuTimeModeLabels[mode]),
+			Content: fmt.Sprintf(
+				"CPU %s占比超标！\n告警级别: %s\n当前占比: %.2f%%\n告警阈值: %.2f%%\n整体使用率: %.2f%%\n%s",
+				cpuTimeModeLabels[mode], level.severity.Label(), b[mode], level.threshold, usage, b.describe(),
+			),
+		})
+	}
+	return samples
+}
@@ -0,0 +1,266 @@
+// internal/collectors/disk.go
+package collectors
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/monitor_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/pkg"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// Disk 磁盘使用率采集器（单独配置了采样间隔的分区使用独立的采集器）
+type Disk struct {
+	cfg      monitor_config.DiskConfig // 磁盘专属配置
+	name     string                    // 采集器名称
+	interval time.Duration             // 采样间隔
+	disks    []string                  // 最终监控的分区列表（创建时确定）
+	rules    map[string]diskRule       // 各分区生效的告警规则（key=分区）
+}
+
+// diskRule 单个分区生效的告警规则（全局配置与分区覆盖配置合并后的结果）
+type diskRule struct {
+	disabled      bool          // 是否禁用告警（仍采集指标）
+	usageWarning  float64       // 使用率警告阈值（%，0表示不按使用率判定）
+	usageCritical float64       // 使用率严重阈值（%）
+	freeWarning   float64       // 剩余空间警告阈值（GB，0表示不按剩余空间判定）
+	freeCritical  float64       // 剩余空间严重阈值（GB）
+	inodeWarning  float64       // inode使用率警告阈值（%，负数或0表示不判定inode）
+	inodeCritical float64       // inode使用率严重阈值（%）
+	forDuration   time.Duration // 持续超阈值多久才触发告警
+}
+
+// NewDisks 创建磁盘采集器（无有效分区时返回nil）
+// 未单独配置采样间隔的分区共用一个采集器，配置了间隔的分区各自使用独立的采集器
+func NewDisks(cfg monitor_config.DiskConfig) []interfaces.Collector {
+	probe := &Disk{cfg: cfg}
+	disks := probe.filterMonitorDisks()
+	if len(disks) == 0 {
+		log.Printf("无有效磁盘分区可监控，跳过磁盘监控")
+		return nil
+	}
+	mounts := probe.matchMountOverrides(disks)
+
+	shared := &Disk{cfg: cfg, name: "磁盘", interval: cfg.Interval.Duration(), rules: make(map[string]diskRule)}
+	var dedicated []interfaces.Collector
+	for _, path := range disks {
+		mount, ok := mounts[path]
+		rule := newDiskRule(cfg, mount, ok)
+		if ok && mount.Interval != 0 && mount.Interval != cfg.Interval {
+			dedicated = append(dedicated, &Disk{
+				cfg:      cfg,
+				name:     fmt.Sprintf("磁盘[%s]", path),
+				interval: mount.Interval.Duration(),
+				disks:    []string{path},
+				rules:    map[string]diskRule{path: rule},
+			})
+			continue
+		}
+		shared.disks = append(shared.disks, path)
+		shared.rules[path] = rule
+	}
+
+	var collectors []interfaces.Collector
+	if len(shared.disks) > 0 {
+		collectors = append(collectors, shared)
+	}
+	return append(collectors, dedicated...)
+}
+
+// newDiskRule 合并全局配置与分区覆盖配置
+func newDiskRule(cfg monitor_config.DiskConfig, mount monitor_config.DiskMountConfig, overridden bool) diskRule {
+	rule := diskRule{
+		usageWarning:  cfg.UsageThreshold,
+		usageCritical: cfg.UsageCriticalThreshold,
+		freeWarning:   cfg.FreeThresholdGB,
+		freeCritical:  cfg.FreeCriticalThresholdGB,
+		inodeWarning:  cfg.InodeThreshold,
+		inodeCritical: cfg.InodeCriticalThreshold,
+		forDuration:   cfg.For.Duration(),
+	}
+	if !overridden {
+		return rule
+	}
+	rule.disabled = mount.Disabled
+	if mount.HasThresholds() {
+		rule.usageWarning, rule.usageCritical = mount.UsageThreshold, mount.UsageCriticalThreshold
+		rule.freeWarning, rule.freeCritical = mount.FreeThresholdGB, mount.FreeCriticalThresholdGB
+	}
+	if mount.InodeThreshold != 0 { // 负数表示仅禁用该分区的inode告警
+		rule.inodeWarning, rule.inodeCritical = mount.InodeThreshold, mount.InodeCriticalThreshold
+	}
+	if mount.For != 0 {
+		rule.forDuration = mount.For.Duration()
+	}
+	return rule
+}
+
+// evaluate 判定分区告警级别（使用率与剩余空间阈值同时配置时，两者均超过才告警，任一恢复即视为恢复）
+func (r diskRule) evaluate(usedPercent, freeGB, hysteresis, freeHysteresis float64) levelResult {
+	usage := evaluateAbove(usedPercent, r.usageWarning, r.usageCritical, hysteresis)
+	free := evaluateBelow(freeGB, r.freeWarning, r.freeCritical, freeHysteresis)
+	switch {
+	case r.disabled:
+		return levelResult{cleared: true}
+	case r.freeWarning == 0:
+		return usage
+	case r.usageWarning == 0:
+		return free
+	}
+
+	result := levelResult{severity: min(usage.severity, free.severity), cleared: usage.cleared || free.cleared}
+	result.threshold = r.usageWarning
+	if result.severity == interfaces.SeverityCritical {
+		result.threshold = r.usageCritical
+	}
+	return result
+}
+
+// evaluateInodes 判定分区inode使用率告警级别（分区禁用或inode阈值为负数时视为正常）
+func (r diskRule) evaluateInodes(usedPercent, hysteresis float64) levelResult {
+	if r.disabled || r.inodeWarning <= 0 {
+		return levelResult{cleared: true}
+	}
+	return evaluateAbove(usedPercent, r.inodeWarning, r.inodeCritical, hysteresis)
+}
+
+// describe 返回规则的阈值描述（用于日志与告警内容）
+func (r diskRule) describe() string {
+	if r.disabled {
+		return "告警已禁用"
+	}
+	var parts []string
+	if r.usageWarning > 0 {
+		part := fmt.Sprintf("使用率警告阈值: %.2f%%", r.usageWarning)
+		if r.usageCritical > 0 {
+			part += fmt.Sprintf(" | 使用率严重阈值: %.2f%%", r.usageCritical)
+		}
+		parts = append(parts, part)
+	}
+	if r.freeWarning > 0 {
+		part := fmt.Sprintf("剩余空间警告阈值: %.2fGB", r.freeWarning)
+		if r.freeCritical > 0 {
+			part += fmt.Sprintf(" | 剩余空间严重阈值: %.2fGB", r.freeCritical)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Name 返回采集器名称
+func (d *Disk) Name() string {
+	return d.name
+}
+
+// Interval 返回采样间隔
+func (d *Disk) Interval() time.Duration {
+	return d.interval
+}
+
+// Collect 采集所有监控分区的空间与inode使用率
+func (d *Disk) Collect() ([]interfaces.Sample, error) {
+	log.Println("开始磁盘监控 | 系统类型:", pkg.GetOS(), "| 监控分区:", d.disks)
+
+	samples := make([]interfaces.Sample, 0, 2*len(d.disks))
+	for _, path := range d.disks {
+		diskUsage, err := disk.Usage(path)
+		if err != nil {
+			log.Printf("分区[%s]监控失败: %v", path, err)
+			continue
+		}
+
+		totalGB := float64(diskUsage.Total) / 1024 / 1024 / 1024
+		usedGB := float64(diskUsage.Used) / 1024 / 1024 / 1024
+		freeGB := float64(diskUsage.Free) / 1024 / 1024 / 1024
+		usedPercent := diskUsage.UsedPercent
+		rule := d.rules[path]
+
+		log.Printf(
+			"磁盘状态 | 分区: %s | 总空间: %.2fGB | 已用: %.2fGB | 剩余: %.2fGB | 使用率: %.2f%% | %s",
+			path, totalGB, usedGB, freeGB, usedPercent, rule.describe(),
+		)
+
+		mountLabel := map[string]string{"mountpoint": path}
+		level := rule.evaluate(usedPercent, freeGB, d.cfg.Hysteresis, d.cfg.FreeHysteresisGB)
+
+		// 仅按剩余空间判定的分区以剩余空间作为告警值
+		value, unit := usedPercent, "%"
+		if rule.usageWarning == 0 && rule.freeWarning > 0 {
+			value, unit = freeGB, "GB"
+		}
+		samples = append(samples, interfaces.Sample{
+			Rule:      "disk_usage",
+			Resource:  path,
+			Labels:    map[string]string{"resource": "disk", "mountpoint": path},
+			Value:     value,
+			Unit:      unit,
+			Severity:  level.severity,
+			Threshold: level.threshold,
+			Cleared:   level.cleared,
+			For:       rule.forDuration,
+			Title:     "磁盘告警",
+			Content: fmt.Sprintf(
+				"分区[%s]空间不足！\n告警级别: %s\n总空间: %.2fGB\n已用: %.2fGB\n剩余: %.2fGB\n当前使用率: %.2f%%\n%s",
+				path, level.severity.Label(), totalGB, usedGB, freeGB, usedPercent, strings.ReplaceAll(rule.describe(), " | ", "\n"),
+			),
+			Metrics: []interfaces.Metric{
+				{Name: "sysmon_disk_total_bytes", Help: "分区总空间（字节）", Labels: mountLabel, Value: float64(diskUsage.Total)},
+				{Name: "sysmon_disk_used_bytes", Help: "分区已用空间（字节）", Labels: mountLabel, Value: float64(diskUsage.Used)},
+				{Name: "sysmon_disk_free_bytes", Help: "分区剩余空间（字节）", Labels: mountLabel, Value: float64(diskUsage.Free)},
+				{Name: "sysmon_disk_used_percent", Help: "分区使用率（%）", Labels: mountLabel, Value: usedPercent},
+			},
+		})
+
+		// 不支持inode的文件系统（如Windows、部分网络文件系统）inode总数为0，跳过inode规则
+		if diskUsage.InodesTotal == 0 {
+			continue
+		}
+		inodePercent := diskUsage.InodesUsedPercent
+		inodeLevel := rule.evaluateInodes(inodePercent, d.cfg.InodeHysteresis)
+		log.Printf(
+			"inode状态 | 分区: %s | inode总数: %d | 已用: %d | 剩余: %d | 使用率: %.2f%% | 警告阈值: %.2f%% | 严重阈值: %.2f%%",
+			path, diskUsage.InodesTotal, diskUsage.InodesUsed, diskUsage.InodesFree, inodePercent, rule.inodeWarning, rule.inodeCritical,
+		)
+		samples = append(samples, interfaces.Sample{
+			Rule:      "disk_inode",
+			Resource:  path,
+			Labels:    map[string]string{"resource": "disk", "mountpoint": path},
+			Value:     inodePercent,
+			Unit:      "%",
+			Severity:  inodeLevel.severity,
+			Threshold: inodeLevel.threshold,
+			Cleared:   inodeLevel.cleared,
+			For:       rule.forDuration,
+			Title:     "磁盘inode告警",
+			Content: fmt.Sprintf(
+				"分区[%s]inode即将耗尽！\n告警级别: %s\ninode总数: %d\n已用: %d\n剩余: %d\n当前inode使用率: %.2f%%\n告警阈值: %.2f%%\n空间使用率: %.2f%%（剩余%.2fGB）",
+				path, inodeLevel.severity.Label(), diskUsage.InodesTotal, diskUsage.InodesUsed, diskUsage.InodesFree, inodePercent, inodeLevel.threshold, usedPercent, freeGB,
+			),
+			Metrics: []interfaces.Metric{
+				{Name: "sysmon_disk_inodes_total", Help: "分区inode总数", Labels: mountLabel, Value: float64(diskUsage.InodesTotal)},
+				{Name: "sysmon_disk_inodes_used", Help: "分区已用inode数", Labels: mountLabel, Value: float64(diskUsage.InodesUsed)},
+				{Name: "sysmon_disk_inodes_free", Help: "分区剩余inode数", Labels: mountLabel, Value: float64(diskUsage.InodesFree)},
+				{Name: "sysmon_disk_inodes_used_percent", Help: "分区inode使用率（%）", Labels: mountLabel, Value: inodePercent},
+			},
+		})
+	}
+	return samples, nil
+}
+
+// Targets 返回最终监控的分区列表
+func (d *Disk) Targets() []string {
+	return d.disks
+}
+
+// Rules 返回规则名（每个分区均有空间与inode两条规则）
+func (d *Disk) Rules() []string {
+	return []string{"disk_usage", "disk_inode"}
+}
+
+var _ interfaces.Collector = (*Disk)(nil)
+var _ interfaces.TargetLister = (*Disk)(nil)
+var _ interfaces.RuleLister = (*Disk)(nil)
@@ -0,0 +1,135 @@
+// internal/collectors/load.go
+package collectors
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/monitor_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/load"
+)
+
+// loadMetrics 负载指标（顺序即规则的输出顺序）
+var loadMetrics = []string{"load1", "load5", "load15", "procs_running", "procs_blocked"}
+
+// loadMetricLabels 负载指标的中文名称
+var loadMetricLabels = map[string]string{
+	"load1":         "1分钟平均负载",
+	"load5":         "5分钟平均负载",
+	"load15":        "15分钟平均负载",
+	"procs_running": "可运行进程数",
+	"procs_blocked": "阻塞进程数",
+}
+
+// Load 系统负载采集器
+type Load struct {
+	cfg monitor_config.LoadConfig // 负载专属配置
+}
+
+// NewLoad 创建系统负载采集器
+func NewLoad(cfg monitor_config.LoadConfig) interfaces.Collector {
+	return &Load{cfg: cfg}
+}
+
+// Name 返回采集器名称
+func (l *Load) Name() string {
+	return "负载"
+}
+
+// Interval 返回采样间隔
+func (l *Load) Interval() time.Duration {
+	return l.cfg.Interval.Duration()
+}
+
+// Rules 返回当前配置下的全部规则名
+func (l *Load) Rules() []string {
+	var rules []string
+	for _, name := range loadMetrics {
+		if _, ok := l.cfg.Thresholds[name]; ok {
+			rules = append(rules, "load_"+name)
+		}
+	}
+	return rules
+}
+
+// Collect 采集平均负载与进程状态
+func (l *Load) Collect() ([]interfaces.Sample, error) {
+	avg, err := load.Avg()
+	if err != nil {
+		return nil, err
+	}
+	// 进程状态在部分系统（如Windows）上不支持，此时跳过进程数规则与指标
+	misc, err := load.Misc()
+	if err != nil {
+		log.Printf("进程状态采集失败，跳过进程数规则: %v", err)
+		misc = nil
+	}
+	cores, err := cpu.Counts(true)
+	if err != nil || cores == 0 {
+		return nil, fmt.Errorf("获取CPU核心数失败: %v", err)
+	}
+
+	values := map[string]float64{
+		"load1":  avg.Load1,
+		"load5":  avg.Load5,
+		"load15": avg.Load15,
+	}
+	metrics := []interfaces.Metric{
+		{Name: "sysmon_load1", Help: "1分钟平均负载", Value: avg.Load1},
+		{Name: "sysmon_load5", Help: "5分钟平均负载", Value: avg.Load5},
+		{Name: "sysmon_load15", Help: "15分钟平均负载", Value: avg.Load15},
+		{Name: "sysmon_cpu_count", Help: "逻辑CPU核心数", Value: float64(cores)},
+	}
+	summary := fmt.Sprintf(
+		"负载: load1 %.2f | load5 %.2f | load15 %.2f\nCPU核心数: %d（每核load1 %.2f）",
+		avg.Load1, avg.Load5, avg.Load15, cores, avg.Load1/float64(cores),
+	)
+	if misc != nil {
+		values["procs_running"] = float64(misc.ProcsRunning)
+		values["procs_blocked"] = float64(misc.ProcsBlocked)
+		metrics = append(metrics,
+			interfaces.Metric{Name: "sysmon_procs_running", Help: "可运行进程数", Value: float64(misc.ProcsRunning)},
+			interfaces.Metric{Name: "sysmon_procs_blocked", Help: "阻塞（不可中断睡眠）进程数", Value: float64(misc.ProcsBlocked)},
+		)
+		summary += fmt.Sprintf("\n可运行进程: %d | 阻塞进程: %d", misc.ProcsRunning, misc.ProcsBlocked)
+	}
+	log.Printf("负载状态 | %s", strings.ReplaceAll(summary, "\n", " | "))
+
+	var samples []interfaces.Sample
+	for _, name := range loadMetrics {
+		threshold, ok := l.cfg.Thresholds[name]
+		value, collected := values[name]
+		if !ok || !collected {
+			continue
+		}
+		unit := ""
+		if threshold.PerCore {
+			value, unit = value/float64(cores), "/核"
+		}
+		level := evaluateAbove(value, threshold.Warning, threshold.Critical, threshold.Hysteresis)
+		samples = append(samples, interfaces.Sample{
+			Rule:      "load_" + name,
+			Resource:  "负载",
+			Labels:    map[string]string{"resource": "load"},
+			Value:     value,
+			Unit:      unit,
+			Severity:  level.severity,
+			Threshold: level.threshold,
+			Cleared:   level.cleared,
+			For:       l.cfg.For.Duration(),
+			Title:     "系统负载告警",
+			Content: fmt.Sprintf(
+				"%s超标！\n告警级别: %s\n当前值: %.2f%s\n告警阈值: %.2f%s\n%s",
+				loadMetricLabels[name], level.severity.Label(), value, unit, level.threshold, unit, summary,
+			),
+		})
+	}
+	return attachMetrics(samples, metrics), nil
+}
+
+var _ interfaces.Collector = (*Load)(nil)
+var _ interfaces.RuleLister = (*Load)(nil)
@@ -0,0 +1,158 @@
+// internal/collectors/mem.go
+package collectors
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/monitor_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// memMetric 内存判定指标
+type memMetric struct {
+	label string // 中文名称
+	unit  string // 数值单位
+	below bool   // 是否"越低越危险"（可用内存类指标）
+}
+
+// memMetrics 支持的内存判定指标（key为配置中的metric）
+var memMetrics = map[string]memMetric{
+	"available_gb":         {label: "可用内存", unit: "GB", below: true},
+	"available_percent":    {label: "可用内存占比", unit: "%", below: true},
+	"used_percent":         {label: "内存使用率（含缓存/缓冲）", unit: "%"},
+	"used_nocache_percent": {label: "内存使用率（不含缓存/缓冲）", unit: "%"},
+}
+
+// Memory 内存采集器
+type Memory struct {
+	cfg   monitor_config.MemConfig // 内存专属配置
+	rules []monitor_config.MemRule // 生效的告警规则（未配置mem_rules时由可用内存阈值生成）
+	swap  swapSampler              // Swap采样（告警内容中附带Swap使用情况）
+}
+
+// NewMemory 创建内存采集器
+func NewMemory(cfg monitor_config.MemConfig) interfaces.Collector {
+	rules := cfg.Rules
+	if len(rules) == 0 {
+		// 兼容旧配置：按可用内存阈值生成规则，规则名保持mem_available
+		rules = []monitor_config.MemRule{{
+			Name:       "mem_available",
+			Metric:     "available_gb",
+			Warning:    cfg.AvailableThreshold,
+			Critical:   cfg.AvailableCriticalThreshold,
+			Hysteresis: cfg.Hysteresis,
+		}}
+	}
+	return &Memory{cfg: cfg, rules: rules}
+}
+
+// Name 返回采集器名称
+func (m *Memory) Name() string {
+	return "内存"
+}
+
+// Interval 返回采样间隔
+func (m *Memory) Interval() time.Duration {
+	return m.cfg.Interval.Duration()
+}
+
+// Rules 返回当前配置下的全部规则名
+func (m *Memory) Rules() []string {
+	rules := make([]string, 0, len(m.rules))
+	for _, rule := range m.rules {
+		rules = append(rules, rule.Name)
+	}
+	return rules
+}
+
+// Collect 采集内存使用情况（每条规则一个采样）
+func (m *Memory) Collect() ([]interfaces.Sample, error) {
+	memInfo, err := mem.VirtualMemory()
+	if err != nil {
+		return nil, err
+	}
+	if memInfo.Total == 0 {
+		return nil, fmt.Errorf("未获取到内存总量")
+	}
+
+	total := float64(memInfo.Total)
+	// 内存使用率按 总内存-空闲 计算（缓存/缓冲计入已用）；gopsutil在Linux下的UsedPercent已扣除缓存，与下方指标重复
+	used := total - float64(memInfo.Free)
+	// 不含缓存/缓冲的已用内存：缓存可随时回收，不代表真实的内存压力
+	noCache := total - float64(memInfo.Free) - float64(memInfo.Buffers) - float64(memInfo.Cached)
+	noCache = max(noCache, 0)
+	values := map[string]float64{
+		"available_gb":         float64(memInfo.Available) / 1024 / 1024 / 1024,
+		"available_percent":    float64(memInfo.Available) / total * 100,
+		"used_percent":         used / total * 100,
+		"used_nocache_percent": noCache / total * 100,
+	}
+
+	summary := fmt.Sprintf(
+		"总内存: %.2fGB\n当前可用: %.2fGB（%.2f%%）\n内存使用率（含缓存/缓冲）: %.2f%%\n不含缓存/缓冲使用率: %.2f%%",
+		total/1024/1024/1024, values["available_gb"], values["available_percent"], values["used_percent"], values["used_nocache_percent"],
+	)
+	log.Printf("内存状态 | %s", strings.ReplaceAll(summary, "\n", " | "))
+
+	// 附带Swap使用情况，便于判断是否已开始频繁换页
+	if swap, err := m.swap.sample(); err != nil {
+		log.Printf("Swap状态采集失败: %v", err)
+	} else {
+		summary += "\n" + swap.describe()
+	}
+
+	samples := make([]interfaces.Sample, 0, len(m.rules))
+	for _, rule := range m.rules {
+		metric, ok := memMetrics[rule.Metric]
+		if !ok {
+			log.Printf("内存规则[%s]的判定指标[%s]未知，跳过", rule.Name, rule.Metric)
+			continue
+		}
+		value := values[rule.Metric]
+		level := evaluateAbove(value, rule.Warning, rule.Critical, rule.Hysteresis)
+		condition := "超过"
+		if metric.below {
+			level = evaluateBelow(value, rule.Warning, rule.Critical, rule.Hysteresis)
+			condition = "低于"
+		}
+		forDuration := m.cfg.For.Duration()
+		if rule.For != 0 {
+			forDuration = rule.For.Duration()
+		}
+		samples = append(samples, interfaces.Sample{
+			Rule:      rule.Name,
+			Resource:  "内存",
+			Labels:    map[string]string{"resource": "mem", "metric": rule.Metric},
+			Value:     value,
+			Unit:      metric.unit,
+			Severity:  level.severity,
+			Threshold: level.threshold,
+			Cleared:   level.cleared,
+			For:       forDuration,
+			Title:     "内存告警",
+			Content: fmt.Sprintf(
+				"%s%s阈值！\n触发指标: %s\n告警级别: %s\n当前值: %.2f%s\n告警阈值: %.2f%s\n%s",
+				metric.label, condition, metric.label, level.severity.Label(), value, metric.unit, level.threshold, metric.unit, summary,
+			),
+		})
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("未配置有效的内存告警规则")
+	}
+
+	samples[0].Metrics = []interfaces.Metric{
+		{Name: "sysmon_memory_total_bytes", Help: "总内存（字节）", Value: total},
+		{Name: "sysmon_memory_available_bytes", Help: "可用内存（字节）", Value: float64(memInfo.Available)},
+		{Name: "sysmon_memory_available_percent", Help: "可用内存占比（%）", Value: values["available_percent"]},
+		{Name: "sysmon_memory_used_percent", Help: "内存使用率（含缓存/缓冲，%）", Value: values["used_percent"]},
+		{Name: "sysmon_memory_used_nocache_percent", Help: "不含缓存/缓冲的内存使用率（%）", Value: values["used_nocache_percent"]},
+	}
+	return samples, nil
+}
+
+var _ interfaces.Collector = (*Memory)(nil)
+var _ interfaces.RuleLister = (*Memory)(nil)
@@ -0,0 +1,64 @@
+// internal/collectors/registry.go
+package collectors
+
+import (
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// 1. 全局注册器：key=采集器名，value=实例化函数（返回空表示不启用，可返回多个实例）
+var collectorRegistry = make(map[string]func(cfg *configs.MonitorConfig) []interfaces.Collector)
+
+// collectorOrder 注册顺序（保证启动顺序稳定）
+var collectorOrder []string
+
+// 2. 注册方法
+func Register(name string, fn func(cfg *configs.MonitorConfig) interfaces.Collector) {
+	RegisterGroup(name, func(cfg *configs.MonitorConfig) []interfaces.Collector {
+		if c := fn(cfg); c != nil {
+			return []interfaces.Collector{c}
+		}
+		return nil
+	})
+}
+
+// RegisterGroup 注册可按配置拆分为多个实例的采集器（如按分区采样间隔拆分的磁盘采集器）
+func RegisterGroup(name string, fn func(cfg *configs.MonitorConfig) []interfaces.Collector) {
+	if _, exists := collectorRegistry[name]; !exists {
+		collectorOrder = append(collectorOrder, name)
+	}
+	collectorRegistry[name] = fn
+}
+
+// 3. 初始化自动注册CPU/内存/Swap/负载/磁盘
+func init() {
+	Register("cpu", func(cfg *configs.MonitorConfig) interfaces.Collector {
+		return NewCPU(cfg.CPU)
+	})
+
+	Register("mem", func(cfg *configs.MonitorConfig) interfaces.Collector {
+		return NewMemory(cfg.Mem)
+	})
+
+	Register("swap", func(cfg *configs.MonitorConfig) interfaces.Collector {
+		return NewSwap(cfg.Swap)
+	})
+
+	Register("load", func(cfg *configs.MonitorConfig) interfaces.Collector {
+		return NewLoad(cfg.Load)
+	})
+
+	RegisterGroup("disk", func(cfg *configs.MonitorConfig) []interfaces.Collector {
+		return NewDisks(cfg.Disk)
+	})
+}
+
+// 4. 创建所有启用的采集器
+// 参数是*configs.MonitorConfig（全局监控配置）
+func GetAll(monitorCfg *configs.MonitorConfig) []interfaces.Collector {
+	var collectors []interfaces.Collector
+	for _, name := range collectorOrder {
+		collectors = append(collectors, collectorRegistry[name](monitorCfg)...)
+	}
+	return collectors
+}
@@ -0,0 +1,14 @@
+// internal/collectors/sample.go
+package collectors
+
+import "github.com/Jwunai/sys-monitor-service/internal/interfaces"
+
+// attachMetrics 将采集器本次的原始指标附加到第一条采样上
+// 未配置任何规则（无采样）时返回一条仅携带指标的采样（Rule为空），保证指标照常导出且不产生虚假的规则与告警状态
+func attachMetrics(samples []interfaces.Sample, metrics []interfaces.Metric) []interfaces.Sample {
+	if len(samples) == 0 {
+		return []interfaces.Sample{{Metrics: metrics}}
+	}
+	samples[0].Metrics = append(samples[0].Metrics, metrics...)
+	return samples
+}
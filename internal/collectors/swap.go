@@ -0,0 +1,156 @@
+// internal/collectors/swap.go
+package collectors
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/monitor_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// swapRules Swap规则（key为配置中的指标名，顺序即规则的输出顺序）
+var swapRules = []struct {
+	key, rule, label, unit string
+}{
+	{"used_percent", "swap_used_percent", "Swap使用率", "%"},
+	{"used_gb", "swap_used_gb", "Swap已用空间", "GB"},
+	{"in_rate", "swap_in_rate", "Swap换入速率", "MB/s"},
+	{"out_rate", "swap_out_rate", "Swap换出速率", "MB/s"},
+}
+
+// swapSnapshot 一次Swap采样结果（速率由两次采样的累计换入/换出字节数求差得到）
+type swapSnapshot struct {
+	stat     *mem.SwapMemoryStat
+	inRate   float64 // 换入速率（MB/s）
+	outRate  float64 // 换出速率（MB/s）
+	hasRates bool    // 是否已有速率（首次采样没有上次数据）
+}
+
+// swapSampler Swap采样器（记录上次的累计换入/换出字节数）
+type swapSampler struct {
+	last   *mem.SwapMemoryStat
+	lastAt time.Time
+}
+
+// sample 读取Swap使用情况并计算换入/换出速率
+func (s *swapSampler) sample() (swapSnapshot, error) {
+	stat, err := mem.SwapMemory()
+	if err != nil {
+		return swapSnapshot{}, err
+	}
+	now := time.Now()
+	snap := swapSnapshot{stat: stat}
+	// 计数器回绕或重置时（当前值小于上次）本次不计算速率
+	if s.last != nil && stat.Sin >= s.last.Sin && stat.Sout >= s.last.Sout {
+		if elapsed := now.Sub(s.lastAt).Seconds(); elapsed > 0 {
+			snap.inRate = float64(stat.Sin-s.last.Sin) / 1024 / 1024 / elapsed
+			snap.outRate = float64(stat.Sout-s.last.Sout) / 1024 / 1024 / elapsed
+			snap.hasRates = true
+		}
+	}
+	s.last, s.lastAt = stat, now
+	return snap, nil
+}
+
+// describe 返回Swap使用情况描述（用于日志与告警内容，内存告警中同样附带）
+func (s swapSnapshot) describe() string {
+	text := fmt.Sprintf(
+		"Swap总量: %.2fGB | 已用: %.2fGB | 使用率: %.2f%%",
+		float64(s.stat.Total)/1024/1024/1024, float64(s.stat.Used)/1024/1024/1024, s.stat.UsedPercent,
+	)
+	if s.hasRates {
+		text += fmt.Sprintf(" | 换入: %.2fMB/s | 换出: %.2fMB/s", s.inRate, s.outRate)
+	}
+	return text
+}
+
+// Swap Swap使用率与换入/换出活动采集器
+type Swap struct {
+	cfg     monitor_config.SwapConfig // Swap专属配置
+	sampler swapSampler               // 换入/换出速率采样
+}
+
+// NewSwap 创建Swap采集器
+func NewSwap(cfg monitor_config.SwapConfig) interfaces.Collector {
+	return &Swap{cfg: cfg}
+}
+
+// Name 返回采集器名称
+func (s *Swap) Name() string {
+	return "Swap"
+}
+
+// Interval 返回采样间隔
+func (s *Swap) Interval() time.Duration {
+	return s.cfg.Interval.Duration()
+}
+
+// Rules 返回当前配置下的全部规则名
+func (s *Swap) Rules() []string {
+	var rules []string
+	for _, r := range swapRules {
+		if _, ok := s.cfg.Thresholds[r.key]; ok {
+			rules = append(rules, r.rule)
+		}
+	}
+	return rules
+}
+
+// Collect 采集Swap使用情况与换入/换出速率
+func (s *Swap) Collect() ([]interfaces.Sample, error) {
+	snap, err := s.sampler.sample()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Swap状态 | %s", snap.describe())
+
+	values := map[string]float64{
+		"used_percent": snap.stat.UsedPercent,
+		"used_gb":      float64(snap.stat.Used) / 1024 / 1024 / 1024,
+	}
+	metrics := []interfaces.Metric{
+		{Name: "sysmon_swap_total_bytes", Help: "Swap总量（字节）", Value: float64(snap.stat.Total)},
+		{Name: "sysmon_swap_used_bytes", Help: "Swap已用（字节）", Value: float64(snap.stat.Used)},
+		{Name: "sysmon_swap_used_percent", Help: "Swap使用率（%）", Value: snap.stat.UsedPercent},
+	}
+	if snap.hasRates {
+		values["in_rate"], values["out_rate"] = snap.inRate, snap.outRate
+		metrics = append(metrics,
+			interfaces.Metric{Name: "sysmon_swap_in_bytes_per_second", Help: "Swap换入速率（字节/秒）", Value: snap.inRate * 1024 * 1024},
+			interfaces.Metric{Name: "sysmon_swap_out_bytes_per_second", Help: "Swap换出速率（字节/秒）", Value: snap.outRate * 1024 * 1024},
+		)
+	}
+
+	var samples []interfaces.Sample
+	for _, r := range swapRules {
+		threshold, ok := s.cfg.Thresholds[r.key]
+		value, collected := values[r.key]
+		if !ok || !collected {
+			continue
+		}
+		level := evaluateAbove(value, threshold.Warning, threshold.Critical, threshold.Hysteresis)
+		samples = append(samples, interfaces.Sample{
+			Rule:      r.rule,
+			Resource:  "Swap",
+			Labels:    map[string]string{"resource": "swap"},
+			Value:     value,
+			Unit:      r.unit,
+			Severity:  level.severity,
+			Threshold: level.threshold,
+			Cleared:   level.cleared,
+			For:       s.cfg.For.Duration(),
+			Title:     "Swap告警",
+			Content: fmt.Sprintf(
+				"%s超标！\n告警级别: %s\n当前值: %.2f%s\n告警阈值: %.2f%s\n%s",
+				r.label, level.severity.Label(), value, r.unit, level.threshold, r.unit, snap.describe(),
+			),
+		})
+	}
+	return attachMetrics(samples, metrics), nil
+}
+
+var _ interfaces.Collector = (*Swap)(nil)
+var _ interfaces.RuleLister = (*Swap)(nil)
@@ -0,0 +1,35 @@
+// internal/collectors/threshold.go
+package collectors
+
+import "github.com/Jwunai/sys-monitor-service/internal/interfaces"
+
+// levelResult 分级阈值判定结果
+type levelResult struct {
+	severity  interfaces.Severity // 当前级别（SeverityNone表示未超过阈值）
+	threshold float64             // 当前级别对应的阈值（未超过时为警告阈值）
+	cleared   bool                // 是否已回到恢复线内（警告阈值±回差）
+}
+
+// evaluateAbove 判定"越高越危险"的指标（如使用率），critical为0表示未配置严重级别
+func evaluateAbove(value, warning, critical, hysteresis float64) levelResult {
+	r := levelResult{threshold: warning, cleared: value <= warning-hysteresis}
+	switch {
+	case critical > 0 && value > critical:
+		r.severity, r.threshold = interfaces.SeverityCritical, critical
+	case value > warning:
+		r.severity = interfaces.SeverityWarning
+	}
+	return r
+}
+
+// evaluateBelow 判定"越低越危险"的指标（如可用内存），critical为0表示未配置严重级别
+func evaluateBelow(value, warning, critical, hysteresis float64) levelResult {
+	r := levelResult{threshold: warning, cleared: value >= warning+hysteresis}
+	switch {
+	case critical > 0 && value < critical:
+		r.severity, r.threshold = interfaces.SeverityCritical, critical
+	case value < warning:
+		r.severity = interfaces.SeverityWarning
+	}
+	return r
+}
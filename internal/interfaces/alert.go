@@ -1,12 +1,72 @@
-// internal/interfaces/alert.go
-package interfaces
-
-// AlertSender 告警发送器通用接口
-type AlertSender interface {
-	// Name 返回告警渠道名称（如"钉钉"、"邮箱"）
-	Name() string
-	// SendAlert 发送告警（title：告警标题，serverName：服务器名称，content：告警内容）
-	SendAlert(title, serverName, content string) error
-	// IsEnabled 判断当前告警渠道是否启用（配置非空）
-	IsEnabled() bool
-}
+// internal/interfaces/alert.go
+package interfaces
+
+import (
+	"fmt"
+	"time"
+)
+
+// AlertState 告警状态
+type AlertState string
+
+const (
+	AlertFiring   AlertState = "firing"   // 告警中
+	AlertResolved AlertState = "resolved" // 已恢复
+)
+
+// Label 返回状态中文名称
+func (s AlertState) Label() string {
+	if s == AlertResolved {
+		return "已恢复"
+	}
+	return "告警中"
+}
+
+// HostInfo 告警来源主机信息
+type HostInfo struct {
+	ServerName string `json:"server_name"` // 服务器名称（配置的server_name）
+	Hostname   string `json:"hostname"`    // 主机名
+	OS         string `json:"os"`          // 系统类型
+}
+
+// Alert 结构化告警（各渠道按字段自行渲染/过滤，无需解析文本）
+type Alert struct {
+	Rule        string            `json:"rule"`              // 规则名（如"cpu_usage"）
+	Resource    string            `json:"resource"`          // 资源标识（如"CPU"、"/data"）
+	Severity    Severity          `json:"severity"`          // 告警级别
+	State       AlertState        `json:"state"`             // 告警状态（firing/resolved）
+	Value       float64           `json:"value"`             // 当前值
+	Threshold   float64           `json:"threshold"`         // 告警阈值
+	Unit        string            `json:"unit"`              // 数值单位（如"%"、"GB"）
+	Labels      map[string]string `json:"labels"`            // 标签（resource/severity/mountpoint/server等）
+	StartsAt    time.Time         `json:"starts_at"`         // 开始超过阈值的时间
+	EndsAt      time.Time         `json:"ends_at,omitempty"` // 恢复时间（仅resolved）
+	Host        HostInfo          `json:"host"`              // 来源主机信息
+	Title       string            `json:"title"`             // 告警标题（如"【严重】CPU告警"）
+	Description string            `json:"description"`       // 告警详情文本
+	Grouped     []Alert           `json:"grouped,omitempty"` // 合并发送的告警明细（限流汇总消息时非空）
+}
+
+// FormatValue 按告警单位格式化数值（如"92.50%"、"1.20GB"）
+func (a Alert) FormatValue(v float64) string {
+	return fmt.Sprintf("%.2f%s", v, a.Unit)
+}
+
+// Duration 返回告警已持续时长（已恢复的告警返回总时长）
+func (a Alert) Duration() time.Duration {
+	end := a.EndsAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(a.StartsAt).Round(time.Second)
+}
+
+// AlertSender 告警发送器通用接口
+type AlertSender interface {
+	// Name 返回告警渠道名称（如"钉钉"、"邮箱"）
+	Name() string
+	// SendAlert 发送结构化告警
+	SendAlert(alert Alert) error
+	// IsEnabled 判断当前告警渠道是否启用（配置非空）
+	IsEnabled() bool
+}
@@ -0,0 +1,54 @@
+// internal/interfaces/collector.go
+package interfaces
+
+import "time"
+
+// Sample 单次采样结果（一个采集器单次可返回多条，如每个磁盘分区一条）
+type Sample struct {
+	Rule      string            // 规则名（如"cpu_usage"），与Resource共同确定告警状态；为空表示仅携带指标的采样
+	Resource  string            // 资源标识（如"CPU"、"/data"）
+	Labels    map[string]string // 资源标签（如resource=disk、mountpoint=/data）
+	Value     float64           // 当前采样值
+	Unit      string            // 数值单位（如"%"、"GB"）
+	Severity  Severity          // 当前告警级别（SeverityNone表示未超过阈值）
+	Threshold float64           // 当前级别对应的阈值（未超过时为警告阈值）
+	Cleared   bool              // 是否已回到恢复线内（警告阈值±回差），用于判定告警恢复
+	For       time.Duration     // 持续超过阈值多久才触发告警（0表示立即触发）
+	Title     string            // 告警标题（如"CPU告警"）
+	Content   string            // 告警内容
+	Metrics   []Metric          // 本次采样的原始指标（供Prometheus导出，与告警判定无关）
+}
+
+// MetricsOnly 判断是否为仅携带指标的采样（采集器未配置任何规则时使用，不参与告警判定与状态展示）
+func (s Sample) MetricsOnly() bool {
+	return s.Rule == ""
+}
+
+// Metric 单个指标值（按Prometheus gauge导出）
+type Metric struct {
+	Name   string            // 指标名（如"sysmon_disk_used_bytes"）
+	Help   string            // 指标说明
+	Labels map[string]string // 指标标签（server_name由导出时统一添加）
+	Value  float64           // 指标值
+}
+
+// Collector 资源采集器通用接口
+type Collector interface {
+	// Name 返回采集器名称（如"CPU"、"内存"）
+	Name() string
+	// Interval 返回采样间隔
+	Interval() time.Duration
+	// Collect 执行一次采样，返回本次所有采样结果
+	Collect() ([]Sample, error)
+}
+
+// TargetLister 可选接口：采集器可列出其监控对象（如磁盘分区），用于状态查询
+type TargetLister interface {
+	Targets() []string
+}
+
+// RuleLister 可选接口：采集器可列出当前配置下的全部规则名，用于热加载后清理已移除规则的告警状态
+// （部分规则依赖两次采样求差，首次采样不产出，因此不能以采样结果判断规则是否仍存在）
+type RuleLister interface {
+	Rules() []string
+}
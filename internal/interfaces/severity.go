@@ -0,0 +1,72 @@
+// internal/interfaces/severity.go
+package interfaces
+
+import "fmt"
+
+// Severity 告警级别
+type Severity int
+
+const (
+	SeverityNone     Severity = iota // 正常（未超过任何阈值）
+	SeverityWarning                  // 警告
+	SeverityCritical                 // 严重
+)
+
+// String 返回级别标识（用于配置与日志，如"warning"）
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "none"
+	}
+}
+
+// Label 返回级别中文名称（用于告警标题与内容展示）
+func (s Severity) Label() string {
+	switch s {
+	case SeverityWarning:
+		return "警告"
+	case SeverityCritical:
+		return "严重"
+	default:
+		return "正常"
+	}
+}
+
+// MarshalText 序列化为级别标识（JSON中以"warning"/"critical"表示）
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText 从级别标识反序列化
+func (s *Severity) UnmarshalText(text []byte) error {
+	sev, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sev
+	return nil
+}
+
+// ParseSeverity 解析配置中的级别标识（空字符串视为不限制，返回SeverityNone）
+func ParseSeverity(s string) (Severity, error) {
+	switch s {
+	case "", "none":
+		return SeverityNone, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "critical":
+		return SeverityCritical, nil
+	default:
+		return SeverityNone, fmt.Errorf("未知的告警级别: %s（可选 warning/critical）", s)
+	}
+}
+
+// SeverityFilter 可选接口：告警渠道按级别过滤（未实现则接收所有级别）
+type SeverityFilter interface {
+	// AcceptSeverity 判断渠道是否接收该级别的告警
+	AcceptSeverity(sev Severity) bool
+}
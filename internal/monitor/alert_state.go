@@ -0,0 +1,152 @@
+// internal/monitor/alert_state.go
+package monitor
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// alertStatus 告警状态
+type alertStatus int
+
+const (
+	statusPending alertStatus = iota // 已超过阈值，等待持续时间满足
+	statusFiring                     // 告警中
+)
+
+// notifyKind 本次采样需要发送的通知类型
+type notifyKind int
+
+const (
+	notifyNone      notifyKind = iota // 无需通知
+	notifyFiring                      // 首次触发告警
+	notifyRepeat                      // 告警持续中，到达重复提醒间隔
+	notifyEscalated                   // 告警级别升级（如警告→严重）
+	notifyResolved                    // 告警已恢复
+)
+
+// alertState 单条规则在单个资源上的告警状态
+type alertState struct {
+	status       alertStatus         // 当前状态
+	severity     interfaces.Severity // 已通知的最高级别（同一轮告警内只升不降，避免级别抖动重复通知）
+	activeSince  time.Time           // 首次超过阈值的时间
+	lastNotified time.Time           // 最近一次发送通知的时间
+	escalateTo   interfaces.Severity // 待升级的级别（需同样持续满for）
+	escalateFrom time.Time           // 开始满足待升级级别的时间
+}
+
+// alertTracker 告警状态跟踪器（按 规则+资源 独立跟踪，如每个磁盘分区单独计时）
+type alertTracker struct {
+	mu             sync.Mutex
+	repeatInterval time.Duration          // 告警持续期间的重复提醒间隔（0表示不重复提醒）
+	states         map[string]*alertState // key=规则名|资源标识，正常的资源不在表中
+}
+
+// newAlertTracker 创建告警状态跟踪器
+func newAlertTracker(repeatInterval time.Duration) *alertTracker {
+	return &alertTracker{
+		repeatInterval: repeatInterval,
+		states:         make(map[string]*alertState),
+	}
+}
+
+// observe 根据采样结果推进状态，返回需要发送的通知类型、告警级别及已持续时长
+// 状态流转：正常 → pending（超过阈值）→ firing（持续满for）→ 恢复（回到 阈值±回差 以内）
+func (t *alertTracker) observe(s interfaces.Sample, now time.Time) (notifyKind, interfaces.Severity, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := s.Rule + "|" + s.Resource
+	state, exists := t.states[key]
+
+	if s.Severity == interfaces.SeverityNone {
+		if !exists {
+			return notifyNone, interfaces.SeverityNone, 0
+		}
+		lasting := now.Sub(state.activeSince)
+		switch {
+		case state.status == statusPending:
+			// 未满持续时间即回落，直接取消
+			log.Printf("规则[%s]资源[%s]已回落到阈值内，取消待触发告警", s.Rule, s.Resource)
+			delete(t.states, key)
+			return notifyNone, interfaces.SeverityNone, 0
+		case s.Cleared:
+			delete(t.states, key)
+			return notifyResolved, state.severity, lasting
+		default:
+			// 处于回差区间内，维持告警状态，避免在阈值附近反复触发/恢复
+			state.escalateTo = interfaces.SeverityNone
+			return notifyNone, state.severity, lasting
+		}
+	}
+
+	// 首次超过阈值：进入pending
+	if !exists {
+		state = &alertState{status: statusPending, activeSince: now}
+		t.states[key] = state
+	}
+
+	lasting := now.Sub(state.activeSince)
+	if state.status == statusPending {
+		if lasting < s.For {
+			log.Printf("规则[%s]资源[%s]超过阈值，已持续%v，满%v后触发告警", s.Rule, s.Resource, lasting.Round(time.Second), s.For)
+			return notifyNone, s.Severity, lasting
+		}
+		state.status = statusFiring
+		state.severity = s.Severity
+		state.lastNotified = now
+		return notifyFiring, state.severity, lasting
+	}
+
+	// 告警中且级别升高：新级别同样持续满for才发送一次升级通知
+	if s.Severity > state.severity {
+		if state.escalateTo != s.Severity {
+			state.escalateTo, state.escalateFrom = s.Severity, now
+		}
+		if now.Sub(state.escalateFrom) >= s.For {
+			log.Printf("规则[%s]资源[%s]告警级别升级: %s → %s", s.Rule, s.Resource, state.severity, s.Severity)
+			state.severity = s.Severity
+			state.escalateTo = interfaces.SeverityNone
+			state.lastNotified = now
+			return notifyEscalated, state.severity, lasting
+		}
+	} else {
+		state.escalateTo = interfaces.SeverityNone
+	}
+
+	// 告警持续中：仅在到达重复提醒间隔时再次通知
+	if t.repeatInterval > 0 && now.Sub(state.lastNotified) >= t.repeatInterval {
+		state.lastNotified = now
+		return notifyRepeat, state.severity, lasting
+	}
+	return notifyNone, state.severity, lasting
+}
+
+// lookup 查询规则在资源上的当前告警状态（正常时返回false）
+func (t *alertTracker) lookup(rule, resource string) (alertState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.states[rule+"|"+resource]
+	if !ok {
+		return alertState{}, false
+	}
+	return *state, true
+}
+
+// setRepeatInterval 更新重复提醒间隔（热加载时调用，已有告警状态保留）
+func (t *alertTracker) setRepeatInterval(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.repeatInterval = d
+}
+
+// forget 删除规则在资源上的告警状态（采集器被移除时调用，不发送恢复通知）
+func (t *alertTracker) forget(rule, resource string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.states, rule+"|"+resource)
+}
@@ -0,0 +1,116 @@
+// internal/monitor/alert_state_test.go
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// observeStep 单次采样及期望的通知结果
+type observeStep struct {
+	at       time.Duration       // 相对起始时间的采样时刻
+	severity interfaces.Severity // 采样级别
+	cleared  bool                // 是否已回到恢复线内
+	wantKind notifyKind          // 期望的通知类型
+	wantSev  interfaces.Severity // 期望的告警级别（仅在有通知时校验）
+}
+
+func TestAlertTrackerObserve(t *testing.T) {
+	const (
+		none     = interfaces.SeverityNone
+		warning  = interfaces.SeverityWarning
+		critical = interfaces.SeverityCritical
+	)
+	cases := []struct {
+		name           string
+		forDuration    time.Duration
+		repeatInterval time.Duration
+		steps          []observeStep
+		wantActive     bool // 最后一步后是否仍有告警状态
+	}{
+		{
+			name:        "未满持续时间即回落，取消且不通知",
+			forDuration: 5 * time.Minute,
+			steps: []observeStep{
+				{at: 0, severity: warning, wantKind: notifyNone},
+				{at: 2 * time.Minute, severity: warning, wantKind: notifyNone},
+				{at: 3 * time.Minute, severity: none, cleared: true, wantKind: notifyNone},
+				{at: 4 * time.Minute, severity: none, cleared: true, wantKind: notifyNone},
+			},
+			wantActive: false,
+		},
+		{
+			name: "处于回差区间内维持告警",
+			steps: []observeStep{
+				{at: 0, severity: warning, wantKind: notifyFiring, wantSev: warning},
+				{at: time.Minute, severity: none, cleared: false, wantKind: notifyNone},
+				{at: 2 * time.Minute, severity: none, cleared: false, wantKind: notifyNone},
+			},
+			wantActive: true,
+		},
+		{
+			name:        "警告升级为严重只通知一次",
+			forDuration: time.Minute,
+			steps: []observeStep{
+				{at: 0, severity: warning, wantKind: notifyNone},
+				{at: time.Minute, severity: warning, wantKind: notifyFiring, wantSev: warning},
+				{at: 2 * time.Minute, severity: critical, wantKind: notifyNone},
+				{at: 3 * time.Minute, severity: critical, wantKind: notifyEscalated, wantSev: critical},
+				{at: 4 * time.Minute, severity: critical, wantKind: notifyNone},
+				{at: 5 * time.Minute, severity: critical, wantKind: notifyNone},
+			},
+			wantActive: true,
+		},
+		{
+			name:           "到达重复提醒间隔才再次通知",
+			repeatInterval: 10 * time.Minute,
+			steps: []observeStep{
+				{at: 0, severity: warning, wantKind: notifyFiring, wantSev: warning},
+				{at: 5 * time.Minute, severity: warning, wantKind: notifyNone},
+				{at: 9 * time.Minute, severity: warning, wantKind: notifyNone},
+				{at: 10 * time.Minute, severity: warning, wantKind: notifyRepeat, wantSev: warning},
+				{at: 15 * time.Minute, severity: warning, wantKind: notifyNone},
+				{at: 20 * time.Minute, severity: warning, wantKind: notifyRepeat, wantSev: warning},
+			},
+			wantActive: true,
+		},
+		{
+			name: "恢复通知携带已通知的最高级别",
+			steps: []observeStep{
+				{at: 0, severity: warning, wantKind: notifyFiring, wantSev: warning},
+				{at: time.Minute, severity: critical, wantKind: notifyEscalated, wantSev: critical},
+				{at: 2 * time.Minute, severity: warning, wantKind: notifyNone},
+				{at: 3 * time.Minute, severity: none, cleared: true, wantKind: notifyResolved, wantSev: critical},
+			},
+			wantActive: false,
+		},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newAlertTracker(tc.repeatInterval)
+			for i, step := range tc.steps {
+				s := interfaces.Sample{
+					Rule:     "test_rule",
+					Resource: "test",
+					Severity: step.severity,
+					Cleared:  step.cleared,
+					For:      tc.forDuration,
+				}
+				kind, sev, _ := tracker.observe(s, start.Add(step.at))
+				if kind != step.wantKind {
+					t.Fatalf("第%d步: 通知类型 = %v，期望 %v", i, kind, step.wantKind)
+				}
+				if kind != notifyNone && sev != step.wantSev {
+					t.Fatalf("第%d步: 告警级别 = %s，期望 %s", i, sev, step.wantSev)
+				}
+			}
+			if _, active := tracker.lookup("test_rule", "test"); active != tc.wantActive {
+				t.Fatalf("告警状态存在 = %v，期望 %v", active, tc.wantActive)
+			}
+		})
+	}
+}
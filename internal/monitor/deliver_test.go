@@ -0,0 +1,105 @@
+// internal/monitor/deliver_test.go
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/internal/outbox"
+	"github.com/Jwunai/sys-monitor-service/pkg"
+)
+
+// scriptedSender 按预设结果依次返回发送结果，并记录每次发送的告警状态
+type scriptedSender struct {
+	results []error
+	sent    []interfaces.AlertState
+}
+
+func (s *scriptedSender) Name() string { return "ops" }
+
+func (s *scriptedSender) IsEnabled() bool { return true }
+
+func (s *scriptedSender) SendAlert(alert interfaces.Alert) error {
+	s.sent = append(s.sent, alert.State)
+	err := s.results[0]
+	s.results = s.results[1:]
+	return err
+}
+
+func TestDeliverResolvedSupersedesQueuedFiring(t *testing.T) {
+	errSend := errors.New("connection refused")
+	firing := interfaces.Alert{Rule: "cpu_usage", Resource: "CPU", State: interfaces.AlertFiring, Title: "【警告】CPU告警"}
+	resolved := firing
+	resolved.State, resolved.Title = interfaces.AlertResolved, "【恢复】CPU告警"
+	other := firing
+	other.Resource = "/data"
+
+	cases := []struct {
+		name       string
+		results    []error            // 依次发送firing、other、resolved的结果
+		wantQueued []interfaces.Alert // 最终队列中剩余的告警（按重试顺序）
+	}{
+		{
+			name:       "恢复通知发送成功，丢弃待重试的告警通知",
+			results:    []error{errSend, errSend, nil},
+			wantQueued: []interfaces.Alert{other},
+		},
+		{
+			name:       "恢复通知发送失败，队列中只保留恢复通知",
+			results:    []error{errSend, errSend, errSend},
+			wantQueued: []interfaces.Alert{other, resolved},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			queuePath := filepath.Join(t.TempDir(), "outbox.json")
+			queue, err := outbox.Open(alert_config.OutboxConfig{
+				Path:           queuePath,
+				MaxAge:         pkg.Duration(time.Hour),
+				InitialBackoff: pkg.Duration(time.Hour),
+				MaxBackoff:     pkg.Duration(time.Hour),
+			})
+			if err != nil {
+				t.Fatalf("打开重试队列失败: %v", err)
+			}
+			sender := &scriptedSender{results: tc.results}
+			m := NewManager("test", nil, map[string]interfaces.AlertSender{sender.Name(): sender}, configs.AlertConfig{}, queue)
+
+			m.deliver(sender, firing)
+			m.deliver(sender, other)
+			m.deliver(sender, resolved)
+
+			wantSent := []interfaces.AlertState{interfaces.AlertFiring, interfaces.AlertFiring, interfaces.AlertResolved}
+			if !slices.Equal(sender.sent, wantSent) {
+				t.Errorf("发送顺序 = %v，期望 %v", sender.sent, wantSent)
+			}
+
+			// 队列按入队顺序重试：恢复之后不能再补发同一规则与资源的告警中通知
+			data, err := os.ReadFile(queuePath)
+			if err != nil {
+				t.Fatalf("读取重试队列文件失败: %v", err)
+			}
+			var items []outbox.Item
+			if err := json.Unmarshal(data, &items); err != nil {
+				t.Fatalf("解析重试队列文件失败: %v", err)
+			}
+			if len(items) != len(tc.wantQueued) {
+				t.Fatalf("队列长度 = %d，期望 %d", len(items), len(tc.wantQueued))
+			}
+			for i, want := range tc.wantQueued {
+				got := items[i].Alert
+				if got.Resource != want.Resource || got.State != want.State {
+					t.Errorf("第%d条待重试告警 = %s/%s，期望 %s/%s", i+1, got.Resource, got.State, want.Resource, want.State)
+				}
+			}
+		})
+	}
+}
@@ -1,93 +1,329 @@
-package monitor
-
-import (
-	"context"
-	"log"
-	"sync"
-
-	"github.com/Jwunai/sys-monitor-service/configs/monitor_config"
-	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
-	"github.com/Jwunai/sys-monitor-service/pkg"
-)
-
-// Manager结构体定义
-type Manager struct {
-	ctx          context.Context           // 退出上下文
-	cancel       context.CancelFunc        // 取消函数
-	serverName   string                    // 服务器名称（告警标识）
-	cpuCfg       monitor_config.CPUConfig  // CPU专属配置（匹配monitor_config包）
-	memCfg       monitor_config.MemConfig  // 内存专属配置
-	diskCfg      monitor_config.DiskConfig // 磁盘专属配置
-	alertSenders []interfaces.AlertSender  // 所有启用的告警实例
-	wg           sync.WaitGroup            // 协程等待组
-}
-
-// 匹配monitor_config包，且字段名大写
-func NewManager(
-	serverName string,
-	cpuCfg monitor_config.CPUConfig,
-	memCfg monitor_config.MemConfig,
-	diskCfg monitor_config.DiskConfig,
-	alertSenders []interfaces.AlertSender,
-) *Manager {
-	ctx, cancel := context.WithCancel(context.Background())
-	return &Manager{
-		ctx:          ctx,
-		cancel:       cancel,
-		serverName:   serverName,
-		cpuCfg:       cpuCfg,
-		memCfg:       memCfg,
-		diskCfg:      diskCfg,
-		alertSenders: alertSenders,
-	}
-}
-
-// Start 启动所有监控协程
-func (m *Manager) Start() {
-	log.Printf(
-		"监控服务启动 | 服务器名称: %s | 系统类型: %s | CPU间隔: %v | 内存间隔: %v | 磁盘间隔: %v",
-		m.serverName, pkg.GetOS(),
-		m.cpuCfg.Interval, m.memCfg.Interval, m.diskCfg.Interval,
-	)
-
-	m.wg.Add(1)
-	go m.monitorCPU()
-
-	m.wg.Add(1)
-	go m.monitorMemory()
-
-	m.wg.Add(1)
-	go m.monitorDisk()
-}
-
-// Stop 停止所有监控协程
-func (m *Manager) Stop() {
-	m.cancel()
-	m.wg.Wait()
-	log.Println("监控服务已完全停止")
-}
-
-// sendAlerts 通用异步告警方法
-func (m *Manager) sendAlerts(title, content string) {
-	if len(m.alertSenders) == 0 {
-		log.Println("⚠️  无启用的告警渠道，跳过告警发送")
-		return
-	}
-
-	for _, sender := range m.alertSenders {
-		go func(s interfaces.AlertSender) {
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("❌ 告警[%s]协程panic: %v", s.Name(), r)
-				}
-			}()
-
-			err := s.SendAlert(title, m.serverName, content)
-			if err != nil {
-				log.Printf("❌ 告警[%s]发送失败: %v", s.Name(), err)
-			} else {
-				log.Printf("✅ 告警[%s]发送成功", s.Name())
-			}
-		}(sender)
-	}
-}
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/internal/outbox"
+	"github.com/Jwunai/sys-monitor-service/pkg"
+)
+
+// Manager结构体定义
+type Manager struct {
+	ctx          context.Context                   // 退出上下文
+	cancel       context.CancelFunc                // 取消函数
+	mu           sync.RWMutex                      // 保护以下可热加载的字段
+	host         interfaces.HostInfo               // 主机信息（告警来源标识）
+	collectors   []interfaces.Collector            // 所有启用的采集器
+	runners      map[string]context.CancelFunc     // 各采集器调度协程的取消函数（key=采集器名）
+	alertSenders map[string]interfaces.AlertSender // 所有启用的告警实例（key=接收器名）
+	router       *router                           // 告警路由（按标签选择接收器）
+	limiters     map[string]*rateLimiter           // 各接收器的限流器（key=接收器名，不限流的接收器不在表中）
+	tracker      *alertTracker                     // 告警状态跟踪（pending/firing/恢复，热加载时保留）
+	outbox       *outbox.Outbox                    // 发送失败重试队列（nil表示不重试）
+	latestMu     sync.RWMutex                      // 保护latest
+	latest       map[string]*collectResult         // 各采集器最近一次采样结果（key=采集器名）
+	wg           sync.WaitGroup                    // 协程等待组
+}
+
+// NewManager 创建监控管理器（采集器由registry按配置创建）
+func NewManager(
+	serverName string,
+	collectors []interfaces.Collector,
+	alertSenders map[string]interfaces.AlertSender,
+	alertCfg configs.AlertConfig,
+	retryQueue *outbox.Outbox,
+) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Manager{
+		ctx:    ctx,
+		cancel: cancel,
+		host: interfaces.HostInfo{
+			ServerName: serverName,
+			Hostname:   pkg.GetHostname(),
+			OS:         pkg.GetOS(),
+		},
+		collectors: collectors,
+		runners:    make(map[string]context.CancelFunc),
+		tracker:    newAlertTracker(alertCfg.RepeatInterval.Duration()),
+		outbox:     retryQueue,
+		latest:     make(map[string]*collectResult),
+	}
+	m.setAlerting(alertSenders, alertCfg)
+	return m
+}
+
+// setAlerting 设置告警实例、路由与限流器（调用方需持有写锁或处于初始化阶段）
+// 接收器限流配置未变化时沿用原限流器，保留令牌与暂存告警
+func (m *Manager) setAlerting(alertSenders map[string]interfaces.AlertSender, alertCfg configs.AlertConfig) {
+	receiverNames := make([]string, 0, len(alertSenders))
+	for name := range alertSenders {
+		receiverNames = append(receiverNames, name)
+	}
+	sort.Strings(receiverNames)
+
+	limiters := make(map[string]*rateLimiter)
+	for _, r := range alertCfg.Receivers {
+		if old, ok := m.limiters[r.Name]; ok && old.cfg == r.RateLimit {
+			limiters[r.Name] = old
+			continue
+		}
+		if l := newRateLimiter(r.RateLimit); l != nil {
+			limiters[r.Name] = l
+		}
+	}
+
+	m.alertSenders = alertSenders
+	m.router = newRouter(alertCfg.Route, receiverNames)
+	m.limiters = limiters
+}
+
+// Start 启动所有监控协程（每个采集器一个协程）
+func (m *Manager) Start() {
+	intervals := make([]string, 0, len(m.collectors))
+	for _, c := range m.collectors {
+		intervals = append(intervals, fmt.Sprintf("%s间隔: %v", c.Name(), c.Interval()))
+	}
+	log.Printf(
+		"监控服务启动 | 服务器名称: %s | 系统类型: %s | %s",
+		m.host.ServerName, m.host.OS, strings.Join(intervals, " | "),
+	)
+
+	m.mu.Lock()
+	for _, c := range m.collectors {
+		m.startRunnerLocked(c)
+	}
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go m.runLimiterFlush()
+
+	if m.outbox != nil {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.outbox.Run(m.ctx, m.resend)
+		}()
+	}
+}
+
+// Stop 停止所有监控协程
+func (m *Manager) Stop() {
+	m.cancel()
+	m.wg.Wait()
+	log.Println("监控服务已完全停止")
+}
+
+// startRunnerLocked 启动采集器调度协程（调用方需持有写锁）
+func (m *Manager) startRunnerLocked(c interfaces.Collector) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.runners[c.Name()] = cancel
+	m.wg.Add(1)
+	go m.runCollector(ctx, c.Name(), c.Interval())
+}
+
+// collector 按名称查找当前生效的采集器（热加载后返回新实例）
+func (m *Manager) collector(name string) interfaces.Collector {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, c := range m.collectors {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// runCollector 采集器调度协程（按间隔采样并处理告警，每次采样使用当前生效的采集器实例）
+func (m *Manager) runCollector(ctx context.Context, name string, interval time.Duration) {
+	defer m.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("%s监控协程已启动", name)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("%s监控协程退出", name)
+			return
+		case <-ticker.C:
+			c := m.collector(name)
+			if c == nil {
+				continue
+			}
+			samples, err := c.Collect()
+			now := time.Now()
+			m.recordResult(name, samples, err, now)
+			if err != nil {
+				log.Printf("%s监控失败: %v", name, err)
+				continue
+			}
+
+			// 按告警状态决定是否通知（首次触发/重复提醒/恢复）
+			for _, s := range samples {
+				if s.MetricsOnly() {
+					continue
+				}
+				kind, sev, lasting := m.tracker.observe(s, now)
+				m.notify(kind, sev, s, now, lasting)
+			}
+		}
+	}
+}
+
+// notify 根据通知类型构造结构化告警并发送（标题携带告警级别）
+func (m *Manager) notify(kind notifyKind, sev interfaces.Severity, s interfaces.Sample, now time.Time, lasting time.Duration) {
+	if kind == notifyNone {
+		return
+	}
+
+	alert := interfaces.Alert{
+		Rule:        s.Rule,
+		Resource:    s.Resource,
+		Severity:    sev,
+		State:       interfaces.AlertFiring,
+		Value:       s.Value,
+		Threshold:   s.Threshold,
+		Unit:        s.Unit,
+		Labels:      m.alertLabels(s, sev),
+		StartsAt:    now.Add(-lasting),
+		Host:        m.Host(),
+		Title:       fmt.Sprintf("【%s】%s", sev.Label(), s.Title),
+		Description: s.Content,
+	}
+	switch kind {
+	case notifyRepeat:
+		alert.Title += "（持续中）"
+	case notifyEscalated:
+		alert.Title += "（级别升级）"
+		alert.Description = fmt.Sprintf("告警级别已升级为: %s\n%s", sev.Label(), s.Content)
+	case notifyResolved:
+		alert.State = interfaces.AlertResolved
+		alert.EndsAt = now
+		alert.Title = fmt.Sprintf("【恢复】%s", s.Title)
+		alert.Description = fmt.Sprintf("[%s]已恢复正常\n原告警级别: %s", s.Resource, sev.Label())
+	}
+	m.sendAlerts(alert)
+}
+
+// alertLabels 合并采样标签与告警公共标签（rule/severity/server）
+func (m *Manager) alertLabels(s interfaces.Sample, sev interfaces.Severity) map[string]string {
+	labels := make(map[string]string, len(s.Labels)+3)
+	for k, v := range s.Labels {
+		labels[k] = v
+	}
+	labels["rule"] = s.Rule
+	labels["severity"] = sev.String()
+	labels["server"] = m.Host().ServerName
+	return labels
+}
+
+// sendAlerts 通用异步告警方法（按路由选择接收器，再按渠道配置的最低级别过滤）
+func (m *Manager) sendAlerts(alert interfaces.Alert) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.alertSenders) == 0 {
+		log.Println("⚠️  无启用的告警渠道，跳过告警发送")
+		return
+	}
+
+	receivers := m.router.match(alert.Labels)
+	if len(receivers) == 0 {
+		log.Printf("⚠️  告警[%s]未匹配到任何接收器，跳过告警发送", alert.Title)
+		return
+	}
+
+	for _, name := range receivers {
+		sender, ok := m.alertSenders[name]
+		if !ok {
+			log.Printf("⚠️  告警接收器[%s]未启用，跳过: %s", name, alert.Title)
+			continue
+		}
+		if f, ok := sender.(interfaces.SeverityFilter); ok && !f.AcceptSeverity(alert.Severity) {
+			log.Printf("告警[%s]低于渠道接收级别，跳过: %s", sender.Name(), alert.Title)
+			continue
+		}
+		if l := m.limiters[name]; l != nil && !l.admit(alert, time.Now()) {
+			log.Printf("⚠️  告警[%s]触发发送限流，暂存待合并发送: %s", sender.Name(), alert.Title)
+			continue
+		}
+		go m.deliver(sender, alert)
+	}
+}
+
+// deliver 发送告警到单个接收器（失败时加入重试队列）
+// 恢复通知发送前先丢弃队列中同一规则与资源的待重试告警，避免恢复后又补发告警中通知
+func (m *Manager) deliver(s interfaces.AlertSender, alert interfaces.Alert) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ 告警[%s]协程panic: %v", s.Name(), r)
+		}
+	}()
+
+	if alert.State == interfaces.AlertResolved && m.outbox != nil {
+		m.outbox.Supersede(s.Name(), alert)
+	}
+
+	err := s.SendAlert(alert)
+	if err != nil {
+		log.Printf("❌ 告警[%s]发送失败: %v", s.Name(), err)
+		if m.outbox != nil {
+			m.outbox.Enqueue(s.Name(), alert, err)
+		}
+	} else {
+		log.Printf("✅ 告警[%s]发送成功", s.Name())
+	}
+}
+
+// runLimiterFlush 限流暂存告警的合并发送协程（令牌恢复后将暂存告警合并为一条汇总消息）
+func (m *Manager) runLimiterFlush() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.mu.RLock()
+			for name, l := range m.limiters {
+				held := l.flush(now)
+				if len(held) == 0 {
+					continue
+				}
+				sender, ok := m.alertSenders[name]
+				if !ok {
+					continue
+				}
+				log.Printf("告警[%s]限流解除，合并发送%d条暂存告警", name, len(held))
+				go m.deliver(sender, coalesceAlerts(held))
+			}
+			m.mu.RUnlock()
+		}
+	}
+}
+
+// resend 重试队列的发送回调（按接收器名称查找当前启用的告警实例，同样受限流约束）
+func (m *Manager) resend(receiver string, alert interfaces.Alert) error {
+	m.mu.RLock()
+	sender, ok := m.alertSenders[receiver]
+	l := m.limiters[receiver]
+	m.mu.RUnlock()
+
+	if !ok {
+		return outbox.ErrReceiverGone
+	}
+	if l != nil && !l.take(time.Now()) {
+		return fmt.Errorf("接收器[%s]发送限流中", receiver)
+	}
+	return sender.SendAlert(alert)
+}
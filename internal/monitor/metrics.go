@@ -0,0 +1,44 @@
+// internal/monitor/metrics.go
+package monitor
+
+import (
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// Metrics 返回最近一次采样的全部指标及各规则的告警状态（供Prometheus导出）
+// 告警状态：sysmon_alert_state 0正常/1待触发/2告警中，sysmon_alert_severity 0正常/1警告/2严重
+func (m *Manager) Metrics() []interfaces.Metric {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.latestMu.RLock()
+	defer m.latestMu.RUnlock()
+
+	var metrics []interfaces.Metric
+	for _, c := range m.collectors {
+		result, ok := m.latest[c.Name()]
+		if !ok {
+			continue
+		}
+		for _, s := range result.samples {
+			metrics = append(metrics, s.Metrics...)
+			if s.MetricsOnly() {
+				continue
+			}
+
+			var status, severity float64
+			if state, ok := m.tracker.lookup(s.Rule, s.Resource); ok {
+				status = 1
+				if state.status == statusFiring {
+					status = 2
+					severity = float64(state.severity)
+				}
+			}
+			labels := map[string]string{"rule": s.Rule, "resource": s.Resource}
+			metrics = append(metrics,
+				interfaces.Metric{Name: "sysmon_alert_state", Help: "告警状态（0正常 1待触发 2告警中）", Labels: labels, Value: status},
+				interfaces.Metric{Name: "sysmon_alert_severity", Help: "告警级别（0正常 1警告 2严重）", Labels: labels, Value: severity},
+			)
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,125 @@
+// internal/monitor/ratelimit.go
+package monitor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// rateLimiter 单个接收器的令牌桶限流器
+// 令牌不足时告警暂存，待令牌恢复后合并为一条汇总消息发送，避免丢失也避免触发渠道限流
+type rateLimiter struct {
+	mu     sync.Mutex
+	cfg    alert_config.RateLimitConfig // 限流配置（热加载时判断是否需要重建）
+	rate   float64                      // 每秒恢复的令牌数
+	burst  float64                      // 令牌桶容量
+	tokens float64                      // 当前令牌数
+	last   time.Time                    // 上次补充令牌的时间
+	held   []interfaces.Alert           // 限流期间暂存、等待合并发送的告警
+}
+
+// newRateLimiter 创建限流器（per_minute<=0表示不限流，返回nil）
+func newRateLimiter(cfg alert_config.RateLimitConfig) *rateLimiter {
+	if cfg.PerMinute <= 0 {
+		return nil
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	return &rateLimiter{
+		cfg:    cfg,
+		rate:   float64(cfg.PerMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refillLocked 按流逝时间补充令牌
+func (l *rateLimiter) refillLocked(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// takeLocked 尝试消耗一个令牌
+func (l *rateLimiter) takeLocked(now time.Time) bool {
+	l.refillLocked(now)
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// admit 判断告警能否立即发送；不能时暂存等待合并（已有暂存告警时同样暂存，保证顺序）
+func (l *rateLimiter) admit(alert interfaces.Alert, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.held) == 0 && l.takeLocked(now) {
+		return true
+	}
+	l.held = append(l.held, alert)
+	return false
+}
+
+// take 尝试消耗一个令牌（供重试队列使用，不暂存）
+func (l *rateLimiter) take(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.takeLocked(now)
+}
+
+// flush 令牌恢复后取出全部暂存告警（无暂存或令牌不足时返回nil）
+func (l *rateLimiter) flush(now time.Time) []interfaces.Alert {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.held) == 0 || !l.takeLocked(now) {
+		return nil
+	}
+	held := l.held
+	l.held = nil
+	return held
+}
+
+// coalesceAlerts 将多条告警合并为一条汇总告警（级别取最高，明细保存在Grouped中由各渠道渲染）
+func coalesceAlerts(alerts []interfaces.Alert) interfaces.Alert {
+	if len(alerts) == 1 {
+		return alerts[0]
+	}
+
+	summary := interfaces.Alert{
+		Rule:     "coalesced",
+		Resource: fmt.Sprintf("%d条告警", len(alerts)),
+		State:    interfaces.AlertResolved,
+		Host:     alerts[0].Host,
+		StartsAt: alerts[0].StartsAt,
+		Grouped:  alerts,
+	}
+	for _, a := range alerts {
+		if a.Severity > summary.Severity {
+			summary.Severity = a.Severity
+		}
+		if a.State == interfaces.AlertFiring {
+			summary.State = interfaces.AlertFiring
+		}
+		if a.StartsAt.Before(summary.StartsAt) {
+			summary.StartsAt = a.StartsAt
+		}
+	}
+	summary.Labels = map[string]string{
+		"rule":     summary.Rule,
+		"severity": summary.Severity.String(),
+		"server":   summary.Host.ServerName,
+	}
+	summary.Title = fmt.Sprintf("【%s】告警汇总（%d条）", summary.Severity.Label(), len(alerts))
+	summary.Description = fmt.Sprintf("触发渠道发送限流，以下%d条告警已合并发送", len(alerts))
+	return summary
+}
@@ -0,0 +1,126 @@
+// internal/monitor/reload.go
+package monitor
+
+import (
+	"log"
+	"strings"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// Reload 热加载配置（配置需由调用方预先校验通过）
+//   - 采样间隔变化或新增的采集器重启调度协程，已移除的采集器停止并清理其告警状态
+//   - 采样间隔未变的采集器沿用原协程，下次采样起使用新阈值
+//   - 保留的采集器中已移除的规则或监控对象（如分区）清除其告警状态
+//   - 告警实例、路由、限流按新配置重建；规则未变的告警状态（pending/firing）保留，不会重复通知
+//
+// 重试队列与HTTP监听地址需重启进程才能生效
+func (m *Manager) Reload(serverName string, collectors []interfaces.Collector, alertSenders map[string]interfaces.AlertSender, alertCfg configs.AlertConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldIntervals := make(map[string]string, len(m.collectors))
+	for _, c := range m.collectors {
+		oldIntervals[c.Name()] = c.Interval().String()
+	}
+
+	var started, restarted, removed []string
+	kept := make(map[string]bool, len(collectors))
+	for _, c := range collectors {
+		name := c.Name()
+		kept[name] = true
+		oldInterval, existed := oldIntervals[name]
+		if existed {
+			m.pruneStaleLocked(c)
+		}
+		switch {
+		case !existed:
+			started = append(started, name)
+		case oldInterval != c.Interval().String():
+			if cancel, ok := m.runners[name]; ok {
+				cancel()
+			}
+			restarted = append(restarted, name)
+		default:
+			continue // 间隔未变，原协程下次采样即使用新实例
+		}
+		m.startRunnerLocked(c)
+	}
+	for name, cancel := range m.runners {
+		if kept[name] {
+			continue
+		}
+		cancel()
+		delete(m.runners, name)
+		m.forgetCollectorLocked(name)
+		removed = append(removed, name)
+	}
+
+	m.host.ServerName = serverName
+	m.collectors = collectors
+	m.setAlerting(alertSenders, alertCfg)
+	m.tracker.setRepeatInterval(alertCfg.RepeatInterval.Duration())
+
+	log.Printf(
+		"配置热加载完成 | 新增采集器: [%s] | 重启采集器: [%s] | 移除采集器: [%s] | 启用告警接收器: %d个",
+		strings.Join(started, ","), strings.Join(restarted, ","), strings.Join(removed, ","), len(alertSenders),
+	)
+}
+
+// pruneStaleLocked 清理保留的采集器中已移除的规则或监控对象的告警状态与最近采样（调用方需持有写锁）
+// 按新配置声明的规则与监控对象判断，被清理的采样若携带采集器指标则保留为仅携带指标的采样
+func (m *Manager) pruneStaleLocked(c interfaces.Collector) {
+	ruleLister, hasRules := c.(interfaces.RuleLister)
+	targetLister, hasTargets := c.(interfaces.TargetLister)
+	if !hasRules && !hasTargets {
+		return
+	}
+	var rules, targets map[string]bool
+	if hasRules {
+		rules = make(map[string]bool)
+		for _, rule := range ruleLister.Rules() {
+			rules[rule] = true
+		}
+	}
+	if hasTargets {
+		targets = make(map[string]bool)
+		for _, target := range targetLister.Targets() {
+			targets[target] = true
+		}
+	}
+
+	m.latestMu.Lock()
+	defer m.latestMu.Unlock()
+
+	result, ok := m.latest[c.Name()]
+	if !ok {
+		return
+	}
+	samples := make([]interfaces.Sample, 0, len(result.samples))
+	for _, s := range result.samples {
+		if s.MetricsOnly() || ((!hasRules || rules[s.Rule]) && (!hasTargets || targets[s.Resource])) {
+			samples = append(samples, s)
+			continue
+		}
+		m.tracker.forget(s.Rule, s.Resource)
+		log.Printf("规则[%s]资源[%s]已从配置中移除，清除其告警状态", s.Rule, s.Resource)
+		if len(s.Metrics) > 0 {
+			samples = append(samples, interfaces.Sample{Metrics: s.Metrics})
+		}
+	}
+	result.samples = samples
+}
+
+// forgetCollectorLocked 清理已移除采集器的最近采样与告警状态（调用方需持有写锁）
+func (m *Manager) forgetCollectorLocked(name string) {
+	m.latestMu.Lock()
+	defer m.latestMu.Unlock()
+
+	if result, ok := m.latest[name]; ok {
+		for _, s := range result.samples {
+			m.tracker.forget(s.Rule, s.Resource)
+		}
+		delete(m.latest, name)
+	}
+}
@@ -0,0 +1,86 @@
+// internal/monitor/reload_test.go
+package monitor
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// ruleCollector 按配置的规则与监控对象返回超过阈值的采样
+type ruleCollector struct {
+	rules     []string
+	resources []string
+}
+
+func (c ruleCollector) Name() string { return "测试" }
+
+func (c ruleCollector) Interval() time.Duration { return time.Minute }
+
+func (c ruleCollector) Rules() []string { return c.rules }
+
+func (c ruleCollector) Targets() []string { return c.resources }
+
+func (c ruleCollector) Collect() ([]interfaces.Sample, error) {
+	var samples []interfaces.Sample
+	for _, rule := range c.rules {
+		for _, resource := range c.resources {
+			samples = append(samples, interfaces.Sample{Rule: rule, Resource: resource, Severity: interfaces.SeverityWarning})
+		}
+	}
+	samples[0].Metrics = []interfaces.Metric{{Name: "sysmon_test", Value: 1}}
+	return samples, nil
+}
+
+func TestReloadPrunesRemovedRules(t *testing.T) {
+	old := ruleCollector{rules: []string{"test_a", "test_b"}, resources: []string{"/", "/data"}}
+	m := NewManager("test", []interfaces.Collector{old}, nil, configs.AlertConfig{}, nil)
+
+	// 模拟一次采样：所有规则均进入告警状态
+	samples, _ := old.Collect()
+	now := time.Now()
+	m.recordResult(old.Name(), samples, nil, now)
+	for _, s := range samples {
+		m.tracker.observe(s, now)
+	}
+
+	// 热加载：移除规则test_a与分区/data，采集器名称与间隔不变（沿用原协程）
+	m.Reload("test", []interfaces.Collector{ruleCollector{rules: []string{"test_b"}, resources: []string{"/"}}}, nil, configs.AlertConfig{})
+
+	cases := []struct {
+		rule, resource string
+		wantActive     bool
+	}{
+		{"test_a", "/", false},
+		{"test_a", "/data", false},
+		{"test_b", "/", true},
+		{"test_b", "/data", false},
+	}
+	for _, tc := range cases {
+		if _, ok := m.tracker.lookup(tc.rule, tc.resource); ok != tc.wantActive {
+			t.Errorf("规则[%s]资源[%s]告警状态存在 = %v，期望 %v", tc.rule, tc.resource, ok, tc.wantActive)
+		}
+	}
+
+	var active []string
+	for _, a := range m.ActiveAlerts() {
+		active = append(active, a.Rule+"@"+a.Resource)
+	}
+	if want := []string{"test_b@/"}; !slices.Equal(active, want) {
+		t.Errorf("活跃告警 = %v，期望 %v", active, want)
+	}
+
+	// 被清理的采样携带的采集器指标仍需导出
+	var exported bool
+	for _, metric := range m.Metrics() {
+		if metric.Name == "sysmon_test" {
+			exported = true
+		}
+	}
+	if !exported {
+		t.Error("清理规则后采集器指标未导出")
+	}
+}
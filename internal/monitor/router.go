@@ -0,0 +1,65 @@
+// internal/monitor/router.go
+package monitor
+
+import (
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config"
+)
+
+// router 告警路由器（按标签匹配路由树，返回接收器名称列表）
+type router struct {
+	root alert_config.RouteConfig // 根路由（默认路由）
+}
+
+// newRouter 创建路由器（根路由未配置接收器时，默认发送到所有启用的接收器）
+func newRouter(root alert_config.RouteConfig, allReceivers []string) *router {
+	if len(root.Receivers) == 0 {
+		root.Receivers = allReceivers
+	}
+	return &router{root: root}
+}
+
+// match 返回告警应发送的接收器名称（去重，保持匹配顺序）
+func (r *router) match(labels map[string]string) []string {
+	var receivers []string
+	seen := make(map[string]bool)
+	for _, name := range matchRoute(r.root, labels) {
+		if !seen[name] {
+			seen[name] = true
+			receivers = append(receivers, name)
+		}
+	}
+	return receivers
+}
+
+// matchRoute 在已命中的路由节点下继续匹配子路由
+func matchRoute(route alert_config.RouteConfig, labels map[string]string) []string {
+	var receivers []string
+	matched := false
+	for _, child := range route.Routes {
+		if !labelsMatch(child.Match, labels) {
+			continue
+		}
+		matched = true
+		if len(child.Receivers) == 0 {
+			child.Receivers = route.Receivers // 未配置接收器时继承父路由
+		}
+		receivers = append(receivers, matchRoute(child, labels)...)
+		if !child.Continue {
+			break
+		}
+	}
+	if !matched {
+		return route.Receivers
+	}
+	return receivers
+}
+
+// labelsMatch 判断告警标签是否满足路由的全部匹配条件
+func labelsMatch(match, labels map[string]string) bool {
+	for k, v := range match {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+// internal/monitor/status.go
+package monitor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+	"github.com/Jwunai/sys-monitor-service/internal/outbox"
+)
+
+// collectResult 采集器最近一次采样结果
+type collectResult struct {
+	samples     []interfaces.Sample // 最近一次成功采样的结果
+	collectedAt time.Time           // 最近一次成功采样时间
+	lastError   string              // 最近一次采样失败原因（成功后清空）
+	lastErrorAt time.Time           // 最近一次采样失败时间
+}
+
+// recordResult 记录采样结果（失败时保留上一次成功的采样）
+func (m *Manager) recordResult(name string, samples []interfaces.Sample, err error, now time.Time) {
+	m.latestMu.Lock()
+	defer m.latestMu.Unlock()
+
+	result, ok := m.latest[name]
+	if !ok {
+		result = &collectResult{}
+		m.latest[name] = result
+	}
+	if err != nil {
+		result.lastError = err.Error()
+		result.lastErrorAt = now
+		return
+	}
+	result.samples = samples
+	result.collectedAt = now
+	result.lastError = ""
+}
+
+// SampleStatus 单条采样结果
+type SampleStatus struct {
+	Rule      string              `json:"rule"`
+	Resource  string              `json:"resource"`
+	Labels    map[string]string   `json:"labels,omitempty"`
+	Value     float64             `json:"value"`
+	Unit      string              `json:"unit"`
+	Severity  interfaces.Severity `json:"severity"`
+	Threshold float64             `json:"threshold"`
+}
+
+// CollectorStatus 采集器状态及最近一次采样结果
+type CollectorStatus struct {
+	Name        string         `json:"name"`
+	Interval    string         `json:"interval"`
+	Targets     []string       `json:"targets,omitempty"`     // 监控对象（如磁盘分区列表）
+	CollectedAt time.Time      `json:"collected_at,omitzero"` // 最近一次成功采样时间（尚未采样时省略）
+	LastError   string         `json:"last_error,omitempty"`
+	LastErrorAt time.Time      `json:"last_error_at,omitzero"`
+	Samples     []SampleStatus `json:"samples"`
+}
+
+// AlertStatus 待触发/告警中的告警
+type AlertStatus struct {
+	Rule         string              `json:"rule"`
+	Resource     string              `json:"resource"`
+	State        string              `json:"state"`    // pending/firing
+	Severity     interfaces.Severity `json:"severity"` // 告警中为已通知级别，待触发为当前采样级别
+	Value        float64             `json:"value"`
+	Threshold    float64             `json:"threshold"`
+	Unit         string              `json:"unit"`
+	StartsAt     time.Time           `json:"starts_at"`
+	LastNotified time.Time           `json:"last_notified,omitzero"`
+}
+
+// Host 返回主机信息
+func (m *Manager) Host() interfaces.HostInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.host
+}
+
+// Collectors 返回所有采集器的状态及最近一次采样结果（按启动顺序）
+func (m *Manager) Collectors() []CollectorStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.latestMu.RLock()
+	defer m.latestMu.RUnlock()
+
+	statuses := make([]CollectorStatus, 0, len(m.collectors))
+	for _, c := range m.collectors {
+		status := CollectorStatus{
+			Name:     c.Name(),
+			Interval: c.Interval().String(),
+			Samples:  []SampleStatus{},
+		}
+		if t, ok := c.(interfaces.TargetLister); ok {
+			status.Targets = t.Targets()
+		}
+		if result, ok := m.latest[c.Name()]; ok {
+			status.CollectedAt = result.collectedAt
+			status.LastError = result.lastError
+			status.LastErrorAt = result.lastErrorAt
+			for _, s := range result.samples {
+				if s.MetricsOnly() {
+					continue
+				}
+				status.Samples = append(status.Samples, SampleStatus{
+					Rule:      s.Rule,
+					Resource:  s.Resource,
+					Labels:    s.Labels,
+					Value:     s.Value,
+					Unit:      s.Unit,
+					Severity:  s.Severity,
+					Threshold: s.Threshold,
+				})
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// ActiveAlerts 返回所有待触发与告警中的告警（按开始时间排序）
+func (m *Manager) ActiveAlerts() []AlertStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.latestMu.RLock()
+	defer m.latestMu.RUnlock()
+
+	alerts := []AlertStatus{}
+	for _, c := range m.collectors {
+		result, ok := m.latest[c.Name()]
+		if !ok {
+			continue
+		}
+		for _, s := range result.samples {
+			state, ok := m.tracker.lookup(s.Rule, s.Resource)
+			if !ok {
+				continue
+			}
+			alert := AlertStatus{
+				Rule:         s.Rule,
+				Resource:     s.Resource,
+				State:        "pending",
+				Severity:     s.Severity,
+				Value:        s.Value,
+				Threshold:    s.Threshold,
+				Unit:         s.Unit,
+				StartsAt:     state.activeSince,
+				LastNotified: state.lastNotified,
+			}
+			if state.status == statusFiring {
+				alert.State = "firing"
+				alert.Severity = state.severity
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+	sort.SliceStable(alerts, func(i, j int) bool { return alerts[i].StartsAt.Before(alerts[j].StartsAt) })
+	return alerts
+}
+
+// Receivers 返回所有启用的告警接收器名称（已排序）
+func (m *Manager) Receivers() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.alertSenders))
+	for name := range m.alertSenders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OutboxStats 返回告警重试队列状态（未启用重试队列时返回false）
+func (m *Manager) OutboxStats() (outbox.Stats, bool) {
+	if m.outbox == nil {
+		return outbox.Stats{}, false
+	}
+	return m.outbox.Stats(), true
+}
@@ -0,0 +1,237 @@
+// internal/outbox/outbox.go
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Jwunai/sys-monitor-service/configs/alert_config"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// checkInterval 检查到期重试项的间隔
+const checkInterval = 5 * time.Second
+
+// statsLogInterval 队列非空时打印队列状态的间隔
+const statsLogInterval = time.Minute
+
+// ErrReceiverGone 重试时目标接收器已不存在（如配置中已删除），该告警直接丢弃
+var ErrReceiverGone = errors.New("告警接收器不存在或未启用")
+
+// Item 待重试的告警
+type Item struct {
+	ID        string           `json:"id"`         // 唯一标识
+	Receiver  string           `json:"receiver"`   // 目标接收器名称
+	Alert     interfaces.Alert `json:"alert"`      // 告警内容
+	CreatedAt time.Time        `json:"created_at"` // 首次发送失败时间
+	Attempts  int              `json:"attempts"`   // 已失败次数
+	NextRetry time.Time        `json:"next_retry"` // 下次重试时间
+	LastError string           `json:"last_error"` // 最近一次失败原因
+}
+
+// Stats 队列状态
+type Stats struct {
+	Depth  int       `json:"depth"`           // 待重试数量
+	Oldest time.Time `json:"oldest,omitzero"` // 最早的待重试告警首次失败时间（队列为空时省略）
+}
+
+// SendFunc 重试发送函数（按接收器名称发送告警）
+type SendFunc func(receiver string, alert interfaces.Alert) error
+
+// Outbox 告警发送失败重试队列（每次变更整体写回文件，保证重启不丢失）
+type Outbox struct {
+	mu    sync.Mutex
+	cfg   alert_config.OutboxConfig
+	items []*Item
+	seq   int64
+}
+
+// Open 打开重试队列（文件不存在时创建空队列）
+func Open(cfg alert_config.OutboxConfig) (*Outbox, error) {
+	o := &Outbox{cfg: cfg}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("创建重试队列目录失败：%w", err)
+	}
+	data, err := os.ReadFile(cfg.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("读取重试队列文件失败：%w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &o.items); err != nil {
+			return nil, fmt.Errorf("解析重试队列文件失败：%w", err)
+		}
+	}
+
+	if len(o.items) > 0 {
+		stats := o.Stats()
+		log.Printf("🔁 已加载告警重试队列 | 待重试: %d | 最早: %s", stats.Depth, stats.Oldest.Format("2006-01-02 15:04:05"))
+	}
+	return o, nil
+}
+
+// Enqueue 将发送失败的告警加入重试队列
+func (o *Outbox) Enqueue(receiver string, alert interfaces.Alert, sendErr error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	o.seq++
+	item := &Item{
+		ID:        fmt.Sprintf("%d-%d", now.UnixNano(), o.seq),
+		Receiver:  receiver,
+		Alert:     alert,
+		CreatedAt: now,
+		Attempts:  1,
+		LastError: sendErr.Error(),
+	}
+	item.NextRetry = now.Add(o.backoff(item.Attempts))
+	o.items = append(o.items, item)
+	o.persistLocked()
+
+	log.Printf("🔁 告警[%s]已加入重试队列，%v后重试 | 队列长度: %d", receiver, item.NextRetry.Sub(now).Round(time.Second), len(o.items))
+}
+
+// Supersede 丢弃同一接收器、同一规则与资源的待重试告警中通知（恢复通知发送或入队时调用，避免恢复后又补发过期的告警中通知）
+func (o *Outbox) Supersede(receiver string, resolved interfaces.Alert) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	kept := o.items[:0]
+	for _, item := range o.items {
+		if item.Receiver == receiver && item.Alert.State == interfaces.AlertFiring &&
+			item.Alert.Rule == resolved.Rule && item.Alert.Resource == resolved.Resource {
+			log.Printf("🔁 告警[%s]已恢复，丢弃待重试的告警通知: %s", receiver, item.Alert.Title)
+			continue
+		}
+		kept = append(kept, item)
+	}
+	if len(kept) == len(o.items) {
+		return
+	}
+	clear(o.items[len(kept):])
+	o.items = kept
+	o.persistLocked()
+}
+
+// Stats 返回队列深度与最早待重试项时间
+func (o *Outbox) Stats() Stats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	stats := Stats{Depth: len(o.items)}
+	for _, item := range o.items {
+		if stats.Oldest.IsZero() || item.CreatedAt.Before(stats.Oldest) {
+			stats.Oldest = item.CreatedAt
+		}
+	}
+	return stats
+}
+
+// Run 重试循环（阻塞直到ctx取消）
+func (o *Outbox) Run(ctx context.Context, send SendFunc) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	var lastStatsLog time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			o.retryDue(now, send)
+
+			if stats := o.Stats(); stats.Depth > 0 && now.Sub(lastStatsLog) >= statsLogInterval {
+				lastStatsLog = now
+				log.Printf("🔁 告警重试队列 | 待重试: %d | 最早: %s", stats.Depth, stats.Oldest.Format("2006-01-02 15:04:05"))
+			}
+		}
+	}
+}
+
+// retryDue 重试所有到期的告警（发送期间不持有锁，避免阻塞入队）
+func (o *Outbox) retryDue(now time.Time, send SendFunc) {
+	o.mu.Lock()
+	var due []*Item
+	for _, item := range o.items {
+		if !item.NextRetry.After(now) {
+			due = append(due, item)
+		}
+	}
+	o.mu.Unlock()
+
+	if len(due) == 0 {
+		return
+	}
+
+	results := make(map[string]error, len(due))
+	for _, item := range due {
+		if now.Sub(item.CreatedAt) > o.cfg.MaxAge.Duration() {
+			continue // 超过最长保留时长，稍后统一丢弃
+		}
+		results[item.ID] = send(item.Receiver, item.Alert)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	kept := o.items[:0]
+	for _, item := range o.items {
+		err, retried := results[item.ID]
+		switch {
+		case now.Sub(item.CreatedAt) > o.cfg.MaxAge.Duration():
+			log.Printf("❌ 告警[%s]重试超过最长保留时长%v，放弃发送: %s（最近错误: %s）", item.Receiver, o.cfg.MaxAge, item.Alert.Title, item.LastError)
+		case !retried:
+			kept = append(kept, item)
+		case err == nil:
+			log.Printf("✅ 告警[%s]第%d次重试发送成功: %s", item.Receiver, item.Attempts, item.Alert.Title)
+		case errors.Is(err, ErrReceiverGone):
+			log.Printf("⚠️  告警[%s]不存在或未启用，放弃重试: %s", item.Receiver, item.Alert.Title)
+		default:
+			item.Attempts++
+			item.LastError = err.Error()
+			item.NextRetry = now.Add(o.backoff(item.Attempts))
+			log.Printf("❌ 告警[%s]第%d次重试失败，%v后再次重试: %v", item.Receiver, item.Attempts-1, item.NextRetry.Sub(now).Round(time.Second), err)
+			kept = append(kept, item)
+		}
+	}
+	o.items = kept
+	o.persistLocked()
+}
+
+// backoff 计算第attempts次失败后的等待时间（指数退避，上限max_backoff，±20%随机抖动）
+func (o *Outbox) backoff(attempts int) time.Duration {
+	wait, maxWait := o.cfg.InitialBackoff.Duration(), o.cfg.MaxBackoff.Duration()
+	for i := 1; i < attempts && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		wait = maxWait
+	}
+	jitter := 0.8 + rand.Float64()*0.4
+	return time.Duration(float64(wait) * jitter)
+}
+
+// persistLocked 将队列写回文件（先写临时文件再重命名，避免写一半时进程退出导致文件损坏）
+func (o *Outbox) persistLocked() {
+	data, err := json.MarshalIndent(o.items, "", "  ")
+	if err != nil {
+		log.Printf("❌ 序列化告警重试队列失败: %v", err)
+		return
+	}
+	tmpPath := o.cfg.Path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		log.Printf("❌ 写入告警重试队列失败: %v", err)
+		return
+	}
+	if err := os.Rename(tmpPath, o.cfg.Path); err != nil {
+		log.Printf("❌ 保存告警重试队列失败: %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package registry
+
+import (
+	"github.com/Jwunai/sys-monitor-service/configs"
+	"github.com/Jwunai/sys-monitor-service/internal/collectors"
+	"github.com/Jwunai/sys-monitor-service/internal/interfaces"
+)
+
+// CreateAllCollectors 创建所有启用的采集器
+func CreateAllCollectors(monitorCfg *configs.MonitorConfig) []interfaces.Collector {
+	return collectors.GetAll(monitorCfg)
+}
@@ -0,0 +1,77 @@
+// pkg/duration.go
+package pkg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration 配置文件中的时长（兼容Go时长字符串与纯数字秒数）
+// 示例：
+// - "30s"、"5m"、"1h30m" → 按Go时长格式解析
+// - 30、"30"、1.5 → 按秒解析（30秒、30秒、1.5秒）
+type Duration time.Duration
+
+// Duration 转换为time.Duration
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// String 返回Go时长格式（如"30s"）
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// UnmarshalYAML 解析YAML中的时长（纯数字按秒）
+// 错误以yaml.TypeError返回，与其他类型错误一样带行号且不中断其余字段的解析
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: 时长必须为\"30s\"、\"5m\"等格式或秒数", value.Line)}}
+	}
+	parsed, err := ParseDuration(value.Value)
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: %v", value.Line, err)}}
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalYAML 序列化为Go时长格式（保证导出的配置可重新加载）
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// MarshalText 序列化为Go时长格式（JSON中以"30s"表示）
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText 解析时长文本（纯数字按秒）
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// ParseDuration 解析时长（纯数字按秒，否则按Go时长格式）
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("无效的时长: %q（应为\"30s\"、\"5m\"等格式或秒数）", s)
+	}
+	return d, nil
+}